@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/backtest"
+	"github.com/agopankov/imPulse/client/internal/baseline"
+	"github.com/agopankov/imPulse/client/internal/strategy"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	dataFlag := flag.String("data", "", "comma separated CSV files or directories with 1m klines")
+	changeFlag := flag.String("change24", "20", "comma separated 24h change thresholds, %")
+	pumpFlag := flag.String("pump", "5", "comma separated pump thresholds, %")
+	waitFlag := flag.String("wait", "15m", "comma separated wait times")
+	baselineFlag := flag.String("baseline", "first", "semicolon separated baselines: first, min 15, vwap 30, lookback 10")
+	cooldownFlag := flag.Duration("cooldown", 0, "per-symbol cooldown between alerts")
+	stepsFlag := flag.String("steps", "", "comma separated re-fire steps, %")
+	retraceFlag := flag.Float64("retrace", 0, "re-arm after a pullback from the peak, %")
+	alertsFlag := flag.String("alerts", "", "write every alert with its forward returns to this CSV file")
+	flag.Parse()
+
+	if *dataFlag == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	changes, err := parseFloats(*changeFlag)
+	if err != nil {
+		log.Fatalf("Invalid -change24: %v", err)
+	}
+	pumps, err := parseFloats(*pumpFlag)
+	if err != nil {
+		log.Fatalf("Invalid -pump: %v", err)
+	}
+	steps, err := parseFloats(*stepsFlag)
+	if err != nil {
+		log.Fatalf("Invalid -steps: %v", err)
+	}
+
+	var waits []time.Duration
+	for _, value := range strings.Split(*waitFlag, ",") {
+		wait, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			log.Fatalf("Invalid -wait: %v", err)
+		}
+		waits = append(waits, wait)
+	}
+
+	var baselines []baseline.Settings
+	for _, value := range strings.Split(*baselineFlag, ";") {
+		settings, err := baseline.Parse(value)
+		if err != nil {
+			log.Fatalf("Invalid -baseline: %v", err)
+		}
+		baselines = append(baselines, settings)
+	}
+
+	data, err := backtest.LoadCSV(strings.Split(*dataFlag, ","))
+	if err != nil {
+		log.Fatalf("Failed to load klines: %v", err)
+	}
+	log.Printf("Loaded klines for %d symbols", len(data))
+
+	var results []backtest.Result
+	for _, change := range changes {
+		for _, pump := range pumps {
+			for _, wait := range waits {
+				for _, baselineSettings := range baselines {
+					results = append(results, backtest.Run(strategy.Settings{
+						ChangePercent24: change,
+						PumpPercent:     pump,
+						WaitTime:        wait,
+						Cooldown:        *cooldownFlag,
+						Steps:           steps,
+						Retrace:         *retraceFlag,
+						Baseline:        baselineSettings,
+					}, data))
+				}
+			}
+		}
+	}
+
+	printResults(results)
+
+	if *alertsFlag != "" {
+		if err := writeAlerts(*alertsFlag, results); err != nil {
+			log.Fatalf("Failed to write alerts: %v", err)
+		}
+	}
+}
+
+func printResults(results []backtest.Result) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "change24\tpump\twait\tbaseline\talerts\t"
+	for _, horizon := range backtest.Horizons {
+		header += fmt.Sprintf("mean %s\tmedian %s\twin %s\t", shortDuration(horizon), shortDuration(horizon), shortDuration(horizon))
+	}
+	fmt.Fprintln(writer, header)
+
+	for _, result := range results {
+		line := fmt.Sprintf("%.2f%%\t%.2f%%\t%s\t%s\t%d\t",
+			result.Settings.ChangePercent24,
+			result.Settings.PumpPercent,
+			shortDuration(result.Settings.WaitTime),
+			result.Settings.Baseline,
+			len(result.Alerts))
+		for _, horizon := range backtest.Horizons {
+			stats, ok := result.Returns[horizon]
+			if !ok {
+				line += "-\t-\t-\t"
+				continue
+			}
+			line += fmt.Sprintf("%+.2f%%\t%+.2f%%\t%.0f%%\t", stats.Mean, stats.Median, stats.WinRate)
+		}
+		fmt.Fprintln(writer, line)
+	}
+
+	if err := writer.Flush(); err != nil {
+		log.Printf("Failed to print results: %v", err)
+	}
+}
+
+func writeAlerts(path string, results []backtest.Result) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{"change24", "pump", "wait", "baseline", "symbol", "time", "price", "baseline_price", "pump_pct", "change_24h"}
+	for _, horizon := range backtest.Horizons {
+		header = append(header, "return_"+shortDuration(horizon))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		for _, alert := range result.Alerts {
+			record := []string{
+				formatFloat(result.Settings.ChangePercent24),
+				formatFloat(result.Settings.PumpPercent),
+				shortDuration(result.Settings.WaitTime),
+				result.Settings.Baseline.String(),
+				alert.Symbol,
+				alert.Time.UTC().Format(time.RFC3339),
+				formatFloat(alert.Price),
+				formatFloat(alert.Baseline),
+				formatFloat(alert.PumpPct),
+				formatFloat(alert.Change24h),
+			}
+			for _, horizon := range backtest.Horizons {
+				value, ok := alert.Returns[horizon]
+				if !ok {
+					record = append(record, "")
+					continue
+				}
+				record = append(record, formatFloat(value))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func parseFloats(text string) ([]float64, error) {
+	var values []float64
+	for _, field := range strings.Split(text, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func shortDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	text := d.String()
+	text = strings.TrimSuffix(text, "0s")
+	return strings.TrimSuffix(text, "0m")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/agopankov/imPulse/client/internal/botcommands"
 	"github.com/agopankov/imPulse/client/internal/cancelfuncs"
 	"github.com/agopankov/imPulse/client/internal/database"
@@ -10,33 +11,29 @@ import (
 	"github.com/agopankov/imPulse/client/internal/telegram"
 	"github.com/agopankov/imPulse/client/internal/user"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
-	tele "gopkg.in/telebot.v3"
 	"log"
 	"os"
-	"time"
+	_ "time/tzdata"
 )
 
 func main() {
 	var db database.Database
 	var err error
 
-	if os.Getenv("DB") == "mongodb" {
+	switch os.Getenv("DB") {
+	case "mongodb":
 		db, err = database.NewMongoDB("mongodb://mongo:27017")
 		if err != nil {
 			log.Fatalf("Failed to connect to MongoDB: %v", err)
 		}
-	} else {
+	case "memory":
+		db = database.NewMemoryDB()
+	default:
 		db = &database.DynamoDB{}
 	}
 
 	userManager := user.NewUserManagerWithDB(db)
 
-	usr := user.NewUser()
-	usr.ChangePercent24.SetPercent(20)
-
-	usr.PumpSettings.SetPumpPercent(5)
-	usr.PumpSettings.SetWaitTime(15 * time.Minute)
-
 	secretsForApplication, err := secrets.LoadSecrets()
 	if err != nil {
 		log.Fatalf("Failed to load secrets: %v", err)
@@ -57,99 +54,65 @@ func main() {
 	}()
 
 	binanceClient := proto.NewBinanceServiceClient(conn)
+	futuresClient := proto.NewFuturesServiceClient(conn)
 
-	telegramClient, err := telegram.NewClient(firstBotToken)
+	telegramClient, err := newTelegramClient(firstBotToken, secretsForApplication.WebhookSecret, "", ":8443")
 	if err != nil {
 		log.Fatalf("Error creating Telegram bot: %v", err)
 	}
 
-	secondTelegramClient, err := telegram.NewClient(secondBotToken)
-	if err != nil {
-		log.Fatalf("Error creating second Telegram bot: %v", err)
+	secondTelegramClient := telegramClient
+	singleBotMode := secondBotToken == ""
+	if singleBotMode {
+		log.Println("TELEGRAM_BOT_TOKEN_SECOND is not set, running in single-bot mode")
+	} else {
+		secondTelegramClient, err = newTelegramClient(secondBotToken, secretsForApplication.WebhookSecretSecond, "_SECOND", ":8444")
+		if err != nil {
+			log.Fatalf("Error creating second Telegram bot: %v", err)
+		}
 	}
 
 	cancelFuncs := cancelfuncs.NewCancelFuncs()
 
 	servicerestartnotification.SendServiceRestartNotifications(db, telegramClient, secondTelegramClient)
 
-	telegramClient.HandleCommand("/start", func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			usr = user.NewUser()
-			usr.ChangePercent24.SetPercent(20)
-			usr.PumpSettings.SetPumpPercent(5)
-			usr.PumpSettings.SetWaitTime(15 * time.Minute)
-			userManager.AddUser(m.Sender.ID, usr)
-		}
-
-		usr.FirstChatID = m.Sender.ID
-		botcommands.StartCommandHandlerFirstClient(m, telegramClient, usr)
-	})
-	telegramClient.HandleCommand("/stop", func(m *tele.Message) {
-		botcommands.StopCommandHandler(m, cancelFuncs)
-	})
-	telegramClient.HandleCommand("/change24percent", func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			log.Printf("Unknown user with ID %d", m.Sender.ID)
-			return
-		}
-
-		botcommands.Change24PercentCommandHandler(m, telegramClient, usr)
-	})
-
-	secondTelegramClient.HandleCommand("/start", func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			usr = user.NewUser()
-			usr.ChangePercent24.SetPercent(20)
-			usr.PumpSettings.SetPumpPercent(5)
-			usr.PumpSettings.SetWaitTime(15 * time.Minute)
-			userManager.AddUser(m.Sender.ID, usr)
-		}
-
-		usr.SecondChatID = m.Sender.ID
-		botcommands.StartCommandHandlerSecondClient(m, secondTelegramClient, usr)
-	})
-	secondTelegramClient.HandleCommand("/setwaittime", func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			log.Printf("Unknown user with ID %d", m.Sender.ID)
-			return
-		}
+	botcommands.RegisterHandlers(telegramClient, secondTelegramClient, userManager, cancelFuncs, binanceClient, futuresClient, postmarkToken)
 
-		botcommands.SetWaitTimeCommandHandler(m, secondTelegramClient, usr)
-	})
-	secondTelegramClient.HandleCommand("/setpumppercent", func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			log.Printf("Unknown user with ID %d", m.Sender.ID)
-			return
-		}
+	if singleBotMode {
+		telegramClient.Start()
+		return
+	}
 
-		botcommands.SetPumpPercentCommandHandler(m, secondTelegramClient, usr)
-	})
+	go secondTelegramClient.Start()
+	telegramClient.Start()
+}
 
-	telegramClient.HandleOnMessage(func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			log.Printf("Unknown user with ID %d", m.Sender.ID)
-			return
-		}
+func newTelegramClient(botToken string, webhookSecret string, envSuffix string, defaultListen string) (*telegram.Client, error) {
+	if apiURL := os.Getenv("TELEGRAM_API_URL"); apiURL != "" {
+		log.Printf("Using Bot API at %s", apiURL)
+		return telegram.NewClientWithAPI(botToken, apiURL)
+	}
 
-		botcommands.MessageHandlerFirstClient(m, telegramClient, secondTelegramClient, cancelFuncs, usr, binanceClient, userManager, postmarkToken)
-	})
+	publicURL := os.Getenv("TELEGRAM_WEBHOOK_URL" + envSuffix)
+	if publicURL == "" {
+		return telegram.NewClient(botToken)
+	}
+	// Without the secret anyone who finds the URL can post fake updates.
+	if webhookSecret == "" {
+		return nil, fmt.Errorf("TELEGRAM_WEBHOOK_URL%s is set but TELEGRAM_WEBHOOK_SECRET%s is not", envSuffix, envSuffix)
+	}
 
-	secondTelegramClient.HandleOnMessage(func(m *tele.Message) {
-		usr, ok := userManager.GetUser(m.Sender.ID)
-		if !ok {
-			log.Printf("Unknown user with ID %d", m.Sender.ID)
-			return
-		}
+	listen := os.Getenv("TELEGRAM_WEBHOOK_LISTEN" + envSuffix)
+	if listen == "" {
+		listen = defaultListen
+	}
 
-		botcommands.MessageHandlerSecondClient(m, secondTelegramClient, usr)
+	log.Printf("Using webhook %s (listening on %s)", publicURL, listen)
+	return telegram.NewWebhookClient(botToken, telegram.WebhookConfig{
+		PublicURL:   publicURL,
+		Listen:      listen,
+		SecretToken: webhookSecret,
+		TLSCert:     os.Getenv("TELEGRAM_WEBHOOK_TLS_CERT"),
+		TLSKey:      os.Getenv("TELEGRAM_WEBHOOK_TLS_KEY"),
 	})
-
-	go secondTelegramClient.Start()
-	telegramClient.Start()
 }
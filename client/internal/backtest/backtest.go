@@ -0,0 +1,212 @@
+package backtest
+
+import (
+	"github.com/agopankov/imPulse/client/internal/baseline"
+	"github.com/agopankov/imPulse/client/internal/strategy"
+	"github.com/agopankov/imPulse/client/internal/tracker"
+	"sort"
+	"time"
+)
+
+var Horizons = []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour}
+
+type Alert struct {
+	Symbol    string
+	Time      time.Time
+	Price     float64
+	Baseline  float64
+	PumpPct   float64
+	Change24h float64
+	Returns   map[time.Duration]float64
+}
+
+type Stats struct {
+	Count   int
+	Mean    float64
+	Median  float64
+	WinRate float64
+}
+
+type Result struct {
+	Settings strategy.Settings
+	Alerts   []Alert
+	Returns  map[time.Duration]Stats
+}
+
+// Run replays the candles through the strategy used by the monitor, treating
+// every candle close as one price tick.
+func Run(settings strategy.Settings, data map[string][]Candle) Result {
+	trackerInstance := tracker.NewTracker()
+	timeline := timeline(data)
+	positions := make(map[string]int, len(data))
+
+	var alerts []Alert
+	for _, now := range timeline {
+		for symbol, candles := range data {
+			index := positions[symbol]
+			if index >= len(candles) || !candles[index].Time.Equal(now) {
+				continue
+			}
+			positions[symbol] = index + 1
+
+			alert, ok := step(settings, trackerInstance, symbol, candles, index)
+			if ok {
+				alert.Returns = forwardReturns(candles, index)
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Time.Before(alerts[j].Time)
+	})
+	return Result{
+		Settings: settings,
+		Alerts:   alerts,
+		Returns:  returnStats(alerts),
+	}
+}
+
+func step(settings strategy.Settings, trackerInstance *tracker.Tracker, symbol string, candles []Candle, index int) (Alert, bool) {
+	candle := candles[index]
+	now := candle.Time
+	price := candle.Close
+	dayAgo, ok := priceAt(candles, index, now.Add(-24*time.Hour))
+	if !ok {
+		return Alert{}, false
+	}
+	change24h := strategy.PumpPercent(dayAgo, price)
+
+	if !trackerInstance.IsTracked(symbol) {
+		if !strategy.ShouldTrack(settings, change24h) {
+			return Alert{}, false
+		}
+		trackerInstance.UpdateTrackedSymbol(strategy.NewSymbol(symbol, price, change24h, now))
+	} else if strategy.ShouldUntrack(settings, change24h) {
+		trackerInstance.RemoveTrackedSymbol(symbol)
+		return Alert{}, false
+	}
+
+	symbolChange := trackerInstance.GetTrackedSymbols()[symbol]
+	symbolChange.PriceChangePct = change24h
+	trackerInstance.RecordPrice(symbol, price, now)
+	if settings.Baseline.Mode == baseline.ModeVWAP {
+		symbolChange.VWAP, _ = vwap(candles, index, settings.Baseline.Window)
+	}
+
+	baselinePrice := strategy.Baseline(settings, symbolChange, trackerInstance.PriceHistory(symbol), now)
+	pumpPct := strategy.PumpPercent(baselinePrice, price)
+
+	if strategy.RearmOnRetrace(settings, &symbolChange, price, now) {
+		trackerInstance.UpdateTrackedSymbol(symbolChange)
+		return Alert{}, false
+	}
+
+	if !strategy.ShouldAlertPump(settings, symbolChange, pumpPct, now) {
+		strategy.TrackPeak(&symbolChange, price)
+		trackerInstance.UpdateTrackedSymbol(symbolChange)
+		return Alert{}, false
+	}
+
+	strategy.MarkPumpAlerted(&symbolChange, pumpPct, price, now)
+	trackerInstance.UpdateTrackedSymbol(symbolChange)
+	return Alert{
+		Symbol:    symbol,
+		Time:      now,
+		Price:     price,
+		Baseline:  baselinePrice,
+		PumpPct:   pumpPct,
+		Change24h: change24h,
+	}, true
+}
+
+func timeline(data map[string][]Candle) []time.Time {
+	seen := make(map[int64]bool)
+	var times []time.Time
+	for _, candles := range data {
+		for _, candle := range candles {
+			if !seen[candle.Time.UnixNano()] {
+				seen[candle.Time.UnixNano()] = true
+				times = append(times, candle.Time)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	return times
+}
+
+// priceAt is the close of the last candle up to at, false while the data
+// doesn't reach back that far. The first day of a file only warms up the
+// 24h change, comparing it to the first candle would track coins the live
+// monitor never would.
+func priceAt(candles []Candle, index int, at time.Time) (float64, bool) {
+	i := sort.Search(index+1, func(i int) bool {
+		return candles[i].Time.After(at)
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return candles[i-1].Close, true
+}
+
+func vwap(candles []Candle, index int, window time.Duration) (float64, bool) {
+	from := candles[index].Time.Add(-window)
+	var prices, volumes []float64
+	for i := index; i >= 0 && candles[i].Time.After(from); i-- {
+		prices = append(prices, (candles[i].High+candles[i].Low+candles[i].Close)/3)
+		volumes = append(volumes, candles[i].Volume)
+	}
+	return baseline.VWAP(prices, volumes)
+}
+
+func forwardReturns(candles []Candle, index int) map[time.Duration]float64 {
+	returns := make(map[time.Duration]float64, len(Horizons))
+	for _, horizon := range Horizons {
+		at := candles[index].Time.Add(horizon)
+		i := sort.Search(len(candles), func(i int) bool {
+			return !candles[i].Time.Before(at)
+		})
+		if i < len(candles) {
+			returns[horizon] = strategy.PumpPercent(candles[index].Close, candles[i].Close)
+		}
+	}
+	return returns
+}
+
+func returnStats(alerts []Alert) map[time.Duration]Stats {
+	stats := make(map[time.Duration]Stats, len(Horizons))
+	for _, horizon := range Horizons {
+		var values []float64
+		for _, alert := range alerts {
+			if value, ok := alert.Returns[horizon]; ok {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		sort.Float64s(values)
+		var sum float64
+		wins := 0
+		for _, value := range values {
+			sum += value
+			if value > 0 {
+				wins++
+			}
+		}
+		median := values[len(values)/2]
+		if len(values)%2 == 0 {
+			median = (values[len(values)/2-1] + values[len(values)/2]) / 2
+		}
+		stats[horizon] = Stats{
+			Count:   len(values),
+			Mean:    sum / float64(len(values)),
+			Median:  median,
+			WinRate: float64(wins) / float64(len(values)) * 100,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,130 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/client/internal/baseline"
+	"github.com/agopankov/imPulse/client/internal/strategy"
+)
+
+var start = time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)
+
+// minuteCandles builds one candle per minute from start, closing at
+// closeAt(i).
+func minuteCandles(count int, closeAt func(i int) float64) []Candle {
+	candles := make([]Candle, count)
+	for i := range candles {
+		price := closeAt(i)
+		candles[i] = Candle{Time: start.Add(time.Duration(i) * time.Minute), Open: price, High: price, Low: price, Close: price, Volume: 1}
+	}
+	return candles
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+var settings = strategy.Settings{
+	ChangePercent24: 10,
+	PumpPercent:     5,
+	WaitTime:        time.Hour,
+	Baseline:        baseline.Settings{Mode: baseline.ModeFirstPrice},
+}
+
+func TestPriceAtNeedsFullDay(t *testing.T) {
+	candles := minuteCandles(1442, func(i int) float64 { return float64(i + 1) })
+
+	if _, ok := priceAt(candles, 1439, candles[1439].Time.Add(-24*time.Hour)); ok {
+		t.Error("got a price 24h back with 1439 minutes of history")
+	}
+	if price, ok := priceAt(candles, 1440, candles[1440].Time.Add(-24*time.Hour)); !ok || price != 1 {
+		t.Errorf("got %v, %v at exactly 24h, want the first close", price, ok)
+	}
+	if price, ok := priceAt(candles, 1441, candles[1441].Time.Add(-24*time.Hour-time.Second)); !ok || price != 1 {
+		t.Errorf("got %v, %v between candles, want the earlier close", price, ok)
+	}
+}
+
+func TestRunSkipsWarmUp(t *testing.T) {
+	// Rises 1% a minute, a pump against the first candle from the second
+	// minute on, but never 24h old.
+	candles := minuteCandles(1440, func(i int) float64 { return math.Pow(1.01, float64(i)) })
+
+	result := Run(settings, map[string][]Candle{"PEPEUSDT": candles})
+	if len(result.Alerts) != 0 {
+		t.Errorf("got %d alerts within the first day, want none: %+v", len(result.Alerts), result.Alerts[0])
+	}
+}
+
+func TestRunAlertAndReturns(t *testing.T) {
+	candles := minuteCandles(1521, func(i int) float64 {
+		switch {
+		case i < 1450:
+			return 1
+		case i == 1450:
+			return 1.2 // 24h change 20%, tracked from here
+		case i < 1456:
+			return 1.26 // 5% above the first tracked price
+		case i < 1466:
+			return 1.386
+		case i < 1511:
+			return 1.134
+		default:
+			return 1.26
+		}
+	})
+
+	result := Run(settings, map[string][]Candle{"PEPEUSDT": candles})
+	if len(result.Alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1: %+v", len(result.Alerts), result.Alerts)
+	}
+	alert := result.Alerts[0]
+	if !alert.Time.Equal(candles[1451].Time) || alert.Baseline != 1.2 || !closeTo(alert.PumpPct, 5) || !closeTo(alert.Change24h, 26) {
+		t.Errorf("alert = %+v", alert)
+	}
+
+	want := map[time.Duration]float64{5 * time.Minute: 10, 15 * time.Minute: -10, time.Hour: 0}
+	for horizon, value := range want {
+		got, ok := alert.Returns[horizon]
+		if !ok || math.Abs(got-value) > 1e-9 {
+			t.Errorf("%v return = %v, %v, want %v", horizon, got, ok, value)
+		}
+		stats := result.Returns[horizon]
+		if stats.Count != 1 || math.Abs(stats.Mean-value) > 1e-9 || math.Abs(stats.Median-value) > 1e-9 {
+			t.Errorf("%v stats = %+v", horizon, stats)
+		}
+	}
+	if result.Returns[5*time.Minute].WinRate != 100 || result.Returns[15*time.Minute].WinRate != 0 {
+		t.Errorf("win rates = %+v", result.Returns)
+	}
+}
+
+func TestForwardReturnsPastTheData(t *testing.T) {
+	candles := minuteCandles(10, func(i int) float64 { return 1 + float64(i)/10 })
+
+	returns := forwardReturns(candles, 2)
+	if got := returns[5*time.Minute]; !closeTo(got, 1.7/1.2*100-100) {
+		t.Errorf("5m return = %v", got)
+	}
+	if _, ok := returns[15*time.Minute]; ok {
+		t.Error("got a 15m return past the last candle")
+	}
+}
+
+func TestReturnStats(t *testing.T) {
+	var alerts []Alert
+	for _, value := range []float64{1, 3, -2, 4} {
+		alerts = append(alerts, Alert{Returns: map[time.Duration]float64{5 * time.Minute: value}})
+	}
+
+	stats := returnStats(alerts)
+	want := Stats{Count: 4, Mean: 1.5, Median: 2, WinRate: 75}
+	if stats[5*time.Minute] != want {
+		t.Errorf("got %+v, want %+v", stats[5*time.Minute], want)
+	}
+	if _, ok := stats[time.Hour]; ok {
+		t.Error("got stats for a horizon without returns")
+	}
+}
@@ -0,0 +1,172 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrParquetUnsupported = errors.New("parquet dumps are not supported, export them to CSV first")
+
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// LoadCSV reads Binance kline dumps. Files with a header row must have
+// symbol, open_time and close columns; headerless files use the Binance
+// data dump layout and take the symbol from the file name (BTCUSDT-1m-2023-05.csv).
+func LoadCSV(paths []string) (map[string][]Candle, error) {
+	data := make(map[string][]Candle)
+	for _, path := range paths {
+		files, err := csvFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := loadFile(file, data); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+	}
+
+	for symbol, candles := range data {
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].Time.Before(candles[j].Time)
+		})
+		data[symbol] = candles
+	}
+	return data, nil
+}
+
+func csvFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if strings.EqualFold(filepath.Ext(path), ".parquet") {
+			return nil, ErrParquetUnsupported
+		}
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".csv":
+			files = append(files, file)
+		case ".parquet":
+			return ErrParquetUnsupported
+		}
+		return nil
+	})
+	return files, err
+}
+
+func loadFile(path string, data map[string][]Candle) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	columns := map[string]int{"open_time": 0, "open": 1, "high": 2, "low": 3, "close": 4, "volume": 5}
+	fileSymbol := strings.ToUpper(strings.SplitN(filepath.Base(path), "-", 2)[0])
+	fileSymbol = strings.TrimSuffix(fileSymbol, strings.ToUpper(filepath.Ext(fileSymbol)))
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if line == 1 {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64); err != nil {
+				columns = make(map[string]int, len(record))
+				for i, name := range record {
+					columns[strings.ToLower(strings.TrimSpace(name))] = i
+				}
+				if _, ok := columns["open_time"]; !ok {
+					return errors.New("header has no open_time column")
+				}
+				if _, ok := columns["close"]; !ok {
+					return errors.New("header has no close column")
+				}
+				continue
+			}
+		}
+
+		symbol := fileSymbol
+		if index, ok := columns["symbol"]; ok && index < len(record) {
+			symbol = strings.ToUpper(strings.TrimSpace(record[index]))
+		}
+
+		candle, err := parseCandle(record, columns)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		data[symbol] = append(data[symbol], candle)
+	}
+}
+
+func parseCandle(record []string, columns map[string]int) (Candle, error) {
+	value := func(name string) (float64, error) {
+		index, ok := columns[name]
+		if !ok || index >= len(record) {
+			return 0, nil
+		}
+		return strconv.ParseFloat(strings.TrimSpace(record[index]), 64)
+	}
+
+	openTime, err := value("open_time")
+	if err != nil {
+		return Candle{}, err
+	}
+	closePrice, err := value("close")
+	if err != nil {
+		return Candle{}, err
+	}
+
+	candle := Candle{Time: parseTimestamp(int64(openTime)), Close: closePrice}
+	if candle.Open, err = value("open"); err != nil {
+		return Candle{}, err
+	}
+	if candle.High, err = value("high"); err != nil {
+		return Candle{}, err
+	}
+	if candle.Low, err = value("low"); err != nil {
+		return Candle{}, err
+	}
+	if candle.Volume, err = value("volume"); err != nil {
+		return Candle{}, err
+	}
+	return candle, nil
+}
+
+func parseTimestamp(value int64) time.Time {
+	if value > 1e15 {
+		return time.UnixMicro(value)
+	}
+	return time.UnixMilli(value)
+}
@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Mode int
+
+const (
+	ModeFirstPrice Mode = iota
+	ModeRollingMin
+	ModeVWAP
+	ModeLookback
+)
+
+const MaxWindow = 4 * time.Hour
+
+var ErrInvalidBaseline = errors.New("baseline must be first, min <minutes>, vwap <minutes> or lookback <minutes>")
+
+type Settings struct {
+	Mode   Mode
+	Window time.Duration
+}
+
+type PricePoint struct {
+	Time  time.Time
+	Price float64
+}
+
+func Parse(text string) (Settings, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return Settings{}, ErrInvalidBaseline
+	}
+
+	var mode Mode
+	switch fields[0] {
+	case "first":
+		if len(fields) != 1 {
+			return Settings{}, ErrInvalidBaseline
+		}
+		return Settings{Mode: ModeFirstPrice}, nil
+	case "min":
+		mode = ModeRollingMin
+	case "vwap":
+		mode = ModeVWAP
+	case "lookback":
+		mode = ModeLookback
+	default:
+		return Settings{}, ErrInvalidBaseline
+	}
+
+	if len(fields) != 2 {
+		return Settings{}, ErrInvalidBaseline
+	}
+	minutes, err := strconv.Atoi(fields[1])
+	if err != nil || minutes <= 0 {
+		return Settings{}, ErrInvalidBaseline
+	}
+	window := time.Duration(minutes) * time.Minute
+	if window > MaxWindow {
+		return Settings{}, fmt.Errorf("baseline window can't be longer than %s", MaxWindow)
+	}
+
+	return Settings{Mode: mode, Window: window}, nil
+}
+
+func (s Settings) String() string {
+	minutes := int(s.Window.Minutes())
+	switch s.Mode {
+	case ModeRollingMin:
+		return fmt.Sprintf("min %d", minutes)
+	case ModeVWAP:
+		return fmt.Sprintf("vwap %d", minutes)
+	case ModeLookback:
+		return fmt.Sprintf("lookback %d", minutes)
+	default:
+		return "first"
+	}
+}
+
+func RollingMin(history []PricePoint, now time.Time, window time.Duration) (float64, bool) {
+	low, ok := 0.0, false
+	for _, point := range history {
+		if now.Sub(point.Time) > window || point.Price <= 0 {
+			continue
+		}
+		if !ok || point.Price < low {
+			low, ok = point.Price, true
+		}
+	}
+	return low, ok
+}
+
+func Lookback(history []PricePoint, now time.Time, window time.Duration) (float64, bool) {
+	for i := len(history) - 1; i >= 0; i-- {
+		if now.Sub(history[i].Time) >= window {
+			return history[i].Price, history[i].Price > 0
+		}
+	}
+	if len(history) > 0 {
+		return history[0].Price, history[0].Price > 0
+	}
+	return 0, false
+}
+
+func VWAP(prices []float64, volumes []float64) (float64, bool) {
+	var notional, volume float64
+	for i := range prices {
+		if i >= len(volumes) {
+			break
+		}
+		notional += prices[i] * volumes[i]
+		volume += volumes[i]
+	}
+	if volume == 0 {
+		return 0, false
+	}
+	return notional / volume, true
+}
@@ -2,30 +2,42 @@ package botcommands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/agopankov/imPulse/client/internal/baseline"
 	"github.com/agopankov/imPulse/client/internal/cancelfuncs"
+	"github.com/agopankov/imPulse/client/internal/database"
+	"github.com/agopankov/imPulse/client/internal/futures"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/client/internal/indicators"
 	"github.com/agopankov/imPulse/client/internal/monitor"
+	"github.com/agopankov/imPulse/client/internal/quiethours"
+	"github.com/agopankov/imPulse/client/internal/rules"
 	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/templates"
 	"github.com/agopankov/imPulse/client/internal/tracker"
 	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/client/internal/venue"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
 	tele "gopkg.in/telebot.v3"
 	"log"
 	"net/mail"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
 func StartCommandHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client, usr *user.User) {
 	log.Printf("Received /start command from chat ID %d", m.Sender.ID)
 	usr.SetState(user.StateAwaitingEmail)
-	sendMessage(telegramClient, m.Sender.ID, "Please enter your email address for verification")
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(usr.GetLanguage(), i18n.EnterEmail))
 }
 
 func StartCommandHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
 	log.Printf("Received /start command from second chat ID %d", m.Sender.ID)
 	usr.SetSecondChatID(m.Sender.ID)
-	sendMessage(secondTelegramClient, m.Sender.ID, "The service for monitoring coins that are being pumped has been launched")
+	sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(usr.GetLanguage(), i18n.SecondBotLaunched))
 }
 
 func StopCommandHandler(m *tele.Message, cancelFuncs *cancelfuncs.CancelFuncs) {
@@ -39,7 +51,8 @@ func Change24PercentCommandHandler(m *tele.Message, telegramClient *telegram.Cli
 	currentPercent24 := usr.ChangePercent24.GetPercent()
 	chatID := m.Sender.ID
 	recipient := &tele.User{ID: chatID}
-	msg := fmt.Sprintf("Please enter the new percent value (current value is %.2f)", currentPercent24)
+	lang := usr.GetLanguage()
+	msg := i18n.T(lang, i18n.EnterChangePercent, i18n.FormatNumber(lang, currentPercent24, 2))
 	if _, err := telegramClient.SendMessage(recipient, msg); err != nil {
 		log.Printf("Error sending message: %v", err)
 	}
@@ -50,24 +63,589 @@ func SetWaitTimeCommandHandler(m *tele.Message, secondTelegramClient *telegram.C
 	currentWaitTime := usr.PumpSettings.GetWaitTime()
 	chatID := m.Sender.ID
 	recipient := &tele.User{ID: chatID}
-	msg := fmt.Sprintf("Please enter the new wait time in minutes (current wait time is %s)", currentWaitTime)
+	lang := usr.GetLanguage()
+	msg := i18n.T(lang, i18n.EnterWaitTime, i18n.FormatNumber(lang, currentWaitTime.Minutes(), 0))
 	if _, err := secondTelegramClient.SendMessage(recipient, msg); err != nil {
 		log.Printf("Error sending message: %v", err)
 	}
 }
 
 func SetPumpPercentCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
-	usr.SetState(user.StateAwaitingPercent)
+	usr.SetState(user.StateAwaitingPumpPercent)
 	currentPumpPercent := usr.PumpSettings.GetPumpPercent()
 	chatID := m.Sender.ID
 	recipient := &tele.User{ID: chatID}
-	msg := fmt.Sprintf("Please enter the new percent value (current percent is %.2f)", currentPumpPercent)
+	lang := usr.GetLanguage()
+	msg := i18n.T(lang, i18n.EnterPumpPercent, i18n.FormatNumber(lang, currentPumpPercent, 2))
 	if _, err := secondTelegramClient.SendMessage(recipient, msg); err != nil {
 		log.Printf("Error sending message: %v", err)
 	}
 }
 
-func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client, secondTelegramClient *telegram.Client, cancelFuncs *cancelfuncs.CancelFuncs, usr *user.User, binanceClient proto.BinanceServiceClient, userManager *user.UserManager, postmarkToken string) {
+func RearmCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
+	log.Printf("Received /rearm command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	fields := strings.Fields(strings.ToLower(m.Payload))
+	if len(fields) == 0 {
+		sendMessage(secondTelegramClient, m.Sender.ID, rearmSettings(lang, usr, i18n.RearmUsage))
+		return
+	}
+
+	if fields[0] == "off" {
+		usr.PumpSettings.SetCooldown(0)
+		usr.PumpSettings.SetSteps(nil)
+		usr.PumpSettings.SetRetrace(0)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RearmDisabled))
+		return
+	}
+
+	var values []float64
+	for _, field := range fields[1:] {
+		value, err := i18n.ParseNumber(field)
+		if err != nil || value < 0 {
+			log.Printf("Invalid /rearm value %q: %v", field, err)
+			sendMessage(secondTelegramClient, m.Sender.ID, rearmSettings(lang, usr, i18n.RearmUsage))
+			return
+		}
+		values = append(values, value)
+	}
+
+	switch {
+	case fields[0] == "cooldown" && len(values) == 1:
+		usr.PumpSettings.SetCooldown(time.Duration(values[0] * float64(time.Minute)))
+	case fields[0] == "step" && len(values) > 0 && !hasZero(values):
+		usr.PumpSettings.SetSteps(values)
+	case fields[0] == "retrace" && len(values) == 1:
+		usr.PumpSettings.SetRetrace(values[0])
+	default:
+		sendMessage(secondTelegramClient, m.Sender.ID, rearmSettings(lang, usr, i18n.RearmUsage))
+		return
+	}
+
+	sendMessage(secondTelegramClient, m.Sender.ID, rearmSettings(lang, usr, i18n.RearmChanged))
+}
+
+// hasZero reports whether one of the values is zero, which makes no sense as
+// a re-arm step.
+func hasZero(values []float64) bool {
+	for _, value := range values {
+		if value == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	leadingGroup   = regexp.MustCompile(`^[-+]?[0-9]{1,3}$`)
+	followingGroup = regexp.MustCompile(`^[0-9]{3}$`)
+	lastGroup      = regexp.MustCompile(`^[0-9]{3}([.,][0-9]+)?$`)
+)
+
+// parseAmount reads one USDT amount from fields. strings.Fields splits an
+// amount typed with space separated digit groups, "250 000", so those are
+// joined back first. Fields that aren't digit groups are an error.
+func parseAmount(fields []string) (float64, error) {
+	if len(fields) > 1 {
+		for i, field := range fields {
+			var group *regexp.Regexp
+			switch i {
+			case 0:
+				group = leadingGroup
+			case len(fields) - 1:
+				group = lastGroup
+			default:
+				group = followingGroup
+			}
+			if !group.MatchString(field) {
+				return 0, fmt.Errorf("%q is not one number", strings.Join(fields, " "))
+			}
+		}
+	}
+	return i18n.ParseNumber(strings.Join(fields, ""))
+}
+
+func BaselineCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
+	log.Printf("Received /baseline command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	settings, err := baseline.Parse(m.Payload)
+	if err != nil {
+		if m.Payload != "" {
+			log.Printf("Invalid baseline value: %v", err)
+		}
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.BaselineUsage, usr.PumpSettings.GetBaseline()))
+		return
+	}
+
+	usr.PumpSettings.SetBaseline(settings)
+	sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.BaselineChanged, settings))
+}
+
+func IndicatorCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, binanceClient proto.BinanceServiceClient, userManager *user.UserManager) {
+	log.Printf("Received /indicator command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	fields := strings.Fields(m.Payload)
+	if len(fields) == 0 {
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+		return
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "add":
+		if len(fields) < 4 {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+			return
+		}
+		signal, err := indicators.ParseSignal(fields[2:])
+		if err != nil {
+			log.Printf("Invalid indicator condition: %v", err)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+			return
+		}
+		symbol := strings.ToUpper(fields[1])
+		if symbol == "ALL" {
+			symbol = ""
+		} else if trading, err := isTradingSymbol(binanceClient, symbol); err != nil {
+			log.Printf("Error checking symbol %s: %v", symbol, err)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorCheckFailed, symbol))
+			return
+		} else if !trading {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUnknownSymbol, symbol))
+			return
+		}
+		id := usr.Indicators.Add(symbol, signal)
+		saveIndicators(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorAdded, id, indicatorSymbol(lang, symbol), signal))
+	case "list":
+		subscriptions := usr.Indicators.List()
+		if len(subscriptions) == 0 {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorNone))
+			return
+		}
+		var builder strings.Builder
+		builder.WriteString(i18n.T(lang, i18n.IndicatorList))
+		for _, subscription := range subscriptions {
+			builder.WriteString(fmt.Sprintf("#%d %s %s\n", subscription.ID, indicatorSymbol(lang, subscription.Symbol), subscription.Signal))
+		}
+		sendMessage(secondTelegramClient, m.Sender.ID, builder.String())
+	case "del", "delete", "remove":
+		if len(fields) != 2 {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+			return
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "#"))
+		if err != nil {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+			return
+		}
+		if !usr.Indicators.Remove(id) {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorNotFound, id))
+			return
+		}
+		saveIndicators(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorRemoved, id))
+	default:
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.IndicatorUsage))
+	}
+}
+
+// isTradingSymbol looks symbol up on its venue, a typo would otherwise be
+// polled for klines forever without ever firing.
+func isTradingSymbol(binanceClient proto.BinanceServiceClient, symbol string) (bool, error) {
+	exchange, pair := venue.Split(symbol)
+	if _, ok := venue.Parse(exchange); !ok {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	symbols, err := binanceClient.GetSymbols(ctx, &proto.MarketRequest{Exchange: exchange})
+	if err != nil {
+		return false, err
+	}
+	for _, listed := range symbols.Symbols {
+		if listed.Symbol == pair {
+			return listed.Trading, nil
+		}
+	}
+	return false, nil
+}
+
+func RuleCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /rule command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	command, argument, _ := strings.Cut(strings.TrimSpace(m.Payload), " ")
+	argument = strings.TrimSpace(argument)
+	usage := i18n.T(lang, i18n.RuleUsage, strings.Join(rules.Variables(), ", "))
+
+	switch strings.ToLower(command) {
+	case "add":
+		if argument == "" {
+			sendMessage(secondTelegramClient, m.Sender.ID, usage)
+			return
+		}
+		expr, err := rules.Parse(argument)
+		if err != nil {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RuleInvalid, err))
+			return
+		}
+		id := usr.Rules.Add(argument, expr)
+		saveRules(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RuleAdded, id))
+	case "list":
+		list := usr.Rules.List()
+		if len(list) == 0 {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RuleNone))
+			return
+		}
+		var builder strings.Builder
+		builder.WriteString(i18n.T(lang, i18n.RuleList))
+		for _, rule := range list {
+			builder.WriteString(fmt.Sprintf("#%d %s\n", rule.ID, rule.Text))
+		}
+		sendMessage(secondTelegramClient, m.Sender.ID, builder.String())
+	case "del", "delete", "remove":
+		id, err := strconv.Atoi(strings.TrimPrefix(argument, "#"))
+		if err != nil {
+			sendMessage(secondTelegramClient, m.Sender.ID, usage)
+			return
+		}
+		if !usr.Rules.Remove(id) {
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RuleNotFound, id))
+			return
+		}
+		saveRules(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.RuleRemoved, id))
+	default:
+		sendMessage(secondTelegramClient, m.Sender.ID, usage)
+	}
+}
+
+func FuturesCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /futures command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	settings := usr.Futures.GetSettings()
+	fields := strings.Fields(strings.ToLower(m.Payload))
+	if len(fields) == 0 {
+		fields = []string{""}
+	}
+
+	switch {
+	case fields[0] == "on" && len(fields) == 1:
+		usr.Futures.SetEnabled(true)
+		saveFutures(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.FuturesEnabled, futuresSettings(lang, settings)...))
+		return
+	case fields[0] == "off" && len(fields) == 1:
+		usr.Futures.SetEnabled(false)
+		saveFutures(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.FuturesDisabled))
+		return
+	case fields[0] == "oi" && len(fields) == 3:
+		percent, percentErr := i18n.ParseNumber(fields[1])
+		minutes, minutesErr := strconv.Atoi(fields[2])
+		if percentErr == nil && minutesErr == nil && percent > 0 && minutes > 0 {
+			settings.OpenInterestPercent = percent
+			settings.OpenInterestWindow = time.Duration(minutes) * time.Minute
+			usr.Futures.SetSettings(settings)
+			saveFutures(userManager.Db, usr)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.FuturesChanged, futuresSettings(lang, settings)...))
+			return
+		}
+	case fields[0] == "funding" && len(fields) == 2:
+		percent, err := i18n.ParseNumber(fields[1])
+		if err == nil && percent > 0 {
+			settings.FundingPercent = percent
+			usr.Futures.SetSettings(settings)
+			saveFutures(userManager.Db, usr)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.FuturesChanged, futuresSettings(lang, settings)...))
+			return
+		}
+	}
+
+	state := i18n.T(lang, i18n.FuturesStateOff)
+	if usr.Futures.IsEnabled() {
+		state = i18n.T(lang, i18n.FuturesStateOn)
+	}
+	args := append([]interface{}{state}, futuresSettings(lang, settings)...)
+	sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.FuturesUsage, args...))
+}
+
+func ListingsCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /listings command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+
+	switch strings.ToLower(strings.TrimSpace(m.Payload)) {
+	case "on":
+		usr.Listings.SetEnabled(true)
+		saveListings(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.ListingsEnabled))
+	case "off":
+		usr.Listings.SetEnabled(false)
+		saveListings(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.ListingsDisabled))
+	default:
+		state := i18n.T(lang, i18n.ListingsStateOff)
+		if usr.Listings.IsEnabled() {
+			state = i18n.T(lang, i18n.ListingsStateOn)
+		}
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.ListingsUsage, state))
+	}
+}
+
+func WhalesCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /whales command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	settings := usr.Whales.GetSettings()
+	fields := strings.Fields(strings.ToLower(m.Payload))
+	if len(fields) == 0 {
+		fields = []string{""}
+	}
+
+	switch {
+	case fields[0] == "on" && len(fields) == 1:
+		usr.Whales.SetEnabled(true)
+		saveWhales(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.WhalesEnabled, whaleSettings(lang, settings)...))
+		return
+	case fields[0] == "off" && len(fields) == 1:
+		usr.Whales.SetEnabled(false)
+		saveWhales(userManager.Db, usr)
+		sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.WhalesDisabled))
+		return
+	case fields[0] == "trade" && len(fields) >= 2:
+		notional, err := parseAmount(fields[1:])
+		if err == nil && notional >= 0 && (notional > 0 || settings.BurstNotional > 0) {
+			settings.MinNotional = notional
+			usr.Whales.SetSettings(settings)
+			saveWhales(userManager.Db, usr)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.WhalesChanged, whaleSettings(lang, settings)...))
+			return
+		}
+	case fields[0] == "burst" && len(fields) >= 2:
+		notional, notionalErr := parseAmount(fields[1:])
+		seconds := int(settings.BurstWindow.Seconds())
+		var secondsErr error
+		if notionalErr != nil && len(fields) >= 3 {
+			notional, notionalErr = parseAmount(fields[1 : len(fields)-1])
+			seconds, secondsErr = strconv.Atoi(fields[len(fields)-1])
+		}
+		if notionalErr == nil && secondsErr == nil && notional >= 0 && seconds > 0 && (notional > 0 || settings.MinNotional > 0) {
+			settings.BurstNotional = notional
+			settings.BurstWindow = time.Duration(seconds) * time.Second
+			usr.Whales.SetSettings(settings)
+			saveWhales(userManager.Db, usr)
+			sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.WhalesChanged, whaleSettings(lang, settings)...))
+			return
+		}
+	}
+
+	state := i18n.T(lang, i18n.WhalesStateOff)
+	if usr.Whales.IsEnabled() {
+		state = i18n.T(lang, i18n.WhalesStateOn)
+	}
+	args := append([]interface{}{state}, whaleSettings(lang, settings)...)
+	sendMessage(secondTelegramClient, m.Sender.ID, i18n.T(lang, i18n.WhalesUsage, args...))
+}
+
+func LiquidityCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /liquidity command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	fields := strings.Fields(strings.ToLower(m.Payload))
+	if len(fields) == 0 {
+		sendMessage(secondTelegramClient, m.Sender.ID, liquiditySettings(lang, usr, i18n.LiquidityUsage))
+		return
+	}
+
+	switch {
+	case fields[0] == "off" && len(fields) == 1:
+		usr.Liquidity.SetMinDepth(0)
+	case fields[0] == "band" && len(fields) == 2:
+		band, err := i18n.ParseNumber(fields[1])
+		if err != nil || band <= 0 || band >= 100 {
+			log.Printf("Invalid /liquidity band %q: %v", fields[1], err)
+			sendMessage(secondTelegramClient, m.Sender.ID, liquiditySettings(lang, usr, i18n.LiquidityUsage))
+			return
+		}
+		usr.Liquidity.SetBand(band)
+	case fields[0] == "min" && len(fields) >= 2:
+		minDepth, err := parseAmount(fields[1:])
+		if err != nil || minDepth < 0 {
+			log.Printf("Invalid /liquidity min %q: %v", strings.Join(fields[1:], " "), err)
+			sendMessage(secondTelegramClient, m.Sender.ID, liquiditySettings(lang, usr, i18n.LiquidityUsage))
+			return
+		}
+		usr.Liquidity.SetMinDepth(minDepth)
+	default:
+		sendMessage(secondTelegramClient, m.Sender.ID, liquiditySettings(lang, usr, i18n.LiquidityUsage))
+		return
+	}
+
+	saveLiquidity(userManager.Db, usr)
+	sendMessage(secondTelegramClient, m.Sender.ID, liquiditySettings(lang, usr, i18n.LiquidityChanged))
+}
+
+func SignalChatCommandHandler(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
+	log.Printf("Received /signalchat command from chat ID %d, thread ID %d", m.Chat.ID, m.ThreadID)
+	lang := usr.GetLanguage()
+	chat, threadID, err := deliveryTarget(m, secondTelegramClient)
+	if err != nil {
+		log.Printf("Rejected /signalchat destination for user ID %d: %v", m.Sender.ID, err)
+		replyInChat(secondTelegramClient, m, i18n.T(lang, i18n.SignalChatRejected, deliveryTargetError(lang, err)))
+		return
+	}
+
+	usr.SetSecondChatID(chat.ID)
+	usr.SetSecondThreadID(threadID)
+	confirmDeliveryTarget(secondTelegramClient, m, lang, chat, threadID, i18n.T(lang, i18n.SignalChatConfirmed))
+}
+
+func ListChatCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User) {
+	log.Printf("Received /listchat command from chat ID %d, thread ID %d", m.Chat.ID, m.ThreadID)
+	lang := usr.GetLanguage()
+	chat, threadID, err := deliveryTarget(m, telegramClient)
+	if err != nil {
+		log.Printf("Rejected /listchat destination for user ID %d: %v", m.Sender.ID, err)
+		replyInChat(telegramClient, m, i18n.T(lang, i18n.ListChatRejected, deliveryTargetError(lang, err)))
+		return
+	}
+
+	usr.SetFirstChatID(chat.ID)
+	usr.SetFirstThreadID(threadID)
+	confirmDeliveryTarget(telegramClient, m, lang, chat, threadID, i18n.T(lang, i18n.ListChatConfirmed))
+}
+
+func TemplateCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User) {
+	log.Printf("Received /template command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	style, ok := templates.ParseStyle(m.Payload)
+	if !ok {
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.TemplateUsage, usr.GetMessageStyle()))
+		return
+	}
+
+	usr.SetMessageStyle(style)
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.TemplateChanged, style))
+}
+
+func LanguageCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /language command from chat ID %d", m.Sender.ID)
+	lang, ok := i18n.ParseLanguage(m.Payload)
+	if !ok {
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(usr.GetLanguage(), i18n.LanguageUsage, usr.GetLanguage()))
+		return
+	}
+
+	usr.SetLanguage(lang)
+	if email := usr.GetEmail(); email != "" {
+		if err := userManager.Db.SaveLanguage(email, string(lang)); err != nil {
+			log.Printf("Error saving language: %v", err)
+		}
+	}
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.LanguageChanged))
+}
+
+func QuietCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /quiet command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	urgentPercent := i18n.FormatPercent(lang, usr.QuietHours.GetUrgentPercent(), false)
+
+	switch strings.ToLower(strings.TrimSpace(m.Payload)) {
+	case "":
+		current := i18n.T(lang, i18n.QuietNotSet)
+		if schedule := usr.QuietHours.GetSchedule(); schedule != nil {
+			current = schedule.String()
+		}
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.QuietUsage, current))
+	case "off":
+		usr.QuietHours.SetSchedule(nil)
+		saveQuietHours(userManager.Db, usr)
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.QuietDisabled))
+	default:
+		schedule, err := quiethours.Parse(m.Payload)
+		if err != nil {
+			log.Printf("Invalid quiet hours value: %v", err)
+			sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.QuietInvalid))
+			return
+		}
+		usr.QuietHours.SetSchedule(schedule)
+		saveQuietHours(userManager.Db, usr)
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.QuietChanged, schedule, urgentPercent))
+	}
+}
+
+func UrgentCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /urgent command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	urgentPercent, err := i18n.ParseNumber(m.Payload)
+	if err != nil {
+		current := i18n.FormatPercent(lang, usr.QuietHours.GetUrgentPercent(), false)
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.UrgentUsage, current))
+		return
+	}
+
+	usr.QuietHours.SetUrgentPercent(urgentPercent)
+	saveQuietHours(userManager.Db, usr)
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.UrgentChanged, i18n.FormatPercent(lang, urgentPercent, false)))
+}
+
+func ExchangesCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /exchanges command from chat ID %d", m.Sender.ID)
+	lang := usr.GetLanguage()
+	supported := strings.Join(venue.Supported, ", ")
+
+	fields := strings.Fields(strings.ReplaceAll(m.Payload, ",", " "))
+	if len(fields) == 0 {
+		current := strings.Join(usr.Exchanges.Get(), ", ")
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.ExchangesUsage, current, supported))
+		return
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		name, ok := venue.Parse(field)
+		if !ok {
+			sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.ExchangesInvalid, field, supported))
+			return
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	usr.Exchanges.Set(names)
+	if err := userManager.Db.SaveExchanges(usr.GetEmail(), names); err != nil {
+		log.Printf("Error saving exchanges: %v", err)
+	}
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.ExchangesChanged, strings.Join(names, ", ")))
+}
+
+func LiveBoardCommandHandler(m *tele.Message, telegramClient *telegram.Client, usr *user.User, userManager *user.UserManager) {
+	log.Printf("Received /liveboard command from chat ID %d", m.Sender.ID)
+	if usr.LiveBoard.IsEnabled() {
+		usr.LiveBoard.SetEnabled(false)
+		if err := userManager.Db.SaveLiveBoard(usr.GetEmail(), 0, 0); err != nil {
+			log.Printf("Error saving live board message: %v", err)
+		}
+		if err := userManager.Db.SaveLiveBoardEnabled(usr.GetEmail(), false); err != nil {
+			log.Printf("Error saving live board setting: %v", err)
+		}
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(usr.GetLanguage(), i18n.LiveBoardDisabled))
+		return
+	}
+
+	usr.LiveBoard.SetEnabled(true)
+	if err := userManager.Db.SaveLiveBoardEnabled(usr.GetEmail(), true); err != nil {
+		log.Printf("Error saving live board setting: %v", err)
+	}
+	sendMessage(telegramClient, m.Sender.ID, i18n.T(usr.GetLanguage(), i18n.LiveBoardEnabled))
+}
+
+func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client, secondTelegramClient *telegram.Client, cancelFuncs *cancelfuncs.CancelFuncs, usr *user.User, binanceClient proto.BinanceServiceClient, futuresClient proto.FuturesServiceClient, userManager *user.UserManager, postmarkToken string) {
+	lang := usr.GetLanguage()
 	switch usr.GetState() {
 	case user.StateAwaitingEmail:
 		email := m.Text
@@ -76,7 +654,7 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 			log.Printf("Invalid email value: %v", err)
 			chatID := m.Sender.ID
 			recipient := &tele.User{ID: chatID}
-			if _, err := telegramClient.SendMessage(recipient, "Invalid email value, please enter a valid email"); err != nil {
+			if _, err := telegramClient.SendMessage(recipient, i18n.T(lang, i18n.InvalidEmail)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			}
 			return
@@ -84,6 +662,7 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 
 		if !userManager.Db.ShouldSendVerificationEmail(email) {
 			chatID := m.Sender.ID
+			usr.SetEmail(email)
 			recipient := &tele.User{ID: chatID}
 
 			trackerInstance := tracker.NewTracker()
@@ -91,9 +670,10 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 			ctx, cancel := context.WithCancel(context.Background())
 			cancelFuncs.Add(chatID, cancel)
 
-			go monitor.PriceChanges(ctx, telegramClient, secondTelegramClient, binanceClient, usr, trackerInstance)
+			restoreUser(userManager.Db, usr)
+			go monitor.PriceChanges(ctx, telegramClient, secondTelegramClient, binanceClient, futuresClient, userManager.Db, usr, trackerInstance)
 
-			if _, err := telegramClient.SendMessage(recipient, "Tracking service launched.\nTo launch the second chatbot, which will receive notifications about the pump of crypto assets, you need to go to it:\n@imPulseSignal_bot\nand send the /start command."); err != nil {
+			if _, err := telegramClient.SendMessage(recipient, launchMessage(usr.GetLanguage(), telegramClient, secondTelegramClient)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			} else {
 				log.Printf("Sent message to chat ID %d: %s", chatID, "Hi")
@@ -106,7 +686,7 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 			userManager.Db.SendVerificationEmail(email, usr.FirstChatID, usr.SecondChatID, postmarkToken)
 
 			recipient := &tele.User{ID: chatID}
-			if _, err := telegramClient.SendMessage(recipient, "A verification code has been sent to your email. Please enter it."); err != nil {
+			if _, err := telegramClient.SendMessage(recipient, i18n.T(lang, i18n.VerificationCodeSent)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			}
 
@@ -124,9 +704,10 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 			ctx, cancel := context.WithCancel(context.Background())
 			cancelFuncs.Add(chatID, cancel)
 
-			go monitor.PriceChanges(ctx, telegramClient, secondTelegramClient, binanceClient, usr, trackerInstance)
+			restoreUser(userManager.Db, usr)
+			go monitor.PriceChanges(ctx, telegramClient, secondTelegramClient, binanceClient, futuresClient, userManager.Db, usr, trackerInstance)
 
-			if _, err := telegramClient.SendMessage(recipient, "Tracking service launched.\nTo launch the second chatbot, which will receive notifications about the pump of crypto assets, you need to go to it:\n@imPulseSignal_bot\nand send the /start command."); err != nil {
+			if _, err := telegramClient.SendMessage(recipient, launchMessage(usr.GetLanguage(), telegramClient, secondTelegramClient)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			} else {
 				log.Printf("Sent message to chat ID %d: %s", chatID, "Hi")
@@ -135,35 +716,36 @@ func MessageHandlerFirstClient(m *tele.Message, telegramClient *telegram.Client,
 		} else {
 			chatID := m.Sender.ID
 			recipient := &tele.User{ID: chatID}
-			if _, err := telegramClient.SendMessage(recipient, "Verification failed. Please enter the correct verification code."); err != nil {
+			if _, err := telegramClient.SendMessage(recipient, i18n.T(lang, i18n.VerificationFailed)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			}
 		}
 
 	case user.StateAwaitingPercent:
-		newPercent, err := strconv.ParseFloat(m.Text, 64)
+		newPercent, err := i18n.ParseNumber(m.Text)
 		if err != nil {
 			log.Printf("Invalid percent value: %v", err)
-			sendMessage(telegramClient, m.Sender.ID, "Invalid percent value, please enter a valid number")
+			sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.InvalidPercent))
 			return
 		}
 		usr.ChangePercent24.SetPercent(newPercent)
 		log.Printf("Percent changed to %f", newPercent)
 		usr.SetState(user.StateNone)
-		sendMessage(telegramClient, m.Sender.ID, "The percentage of pumping for tracked coins has been changed")
+		sendMessage(telegramClient, m.Sender.ID, i18n.T(lang, i18n.ChangePercentChanged))
 	}
 }
 
 func MessageHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.Client, usr *user.User) {
+	lang := usr.GetLanguage()
 	switch usr.GetState() {
-	case user.StateAwaitingPercent:
-		pumpPercent, err := strconv.ParseFloat(m.Text, 64)
+	case user.StateAwaitingPumpPercent:
+		pumpPercent, err := i18n.ParseNumber(m.Text)
 		if err != nil {
 			log.Printf("Invalid percent value: %v", err)
 
 			chatID := m.Sender.ID
 			recipient := &tele.User{ID: chatID}
-			if _, err := secondTelegramClient.SendMessage(recipient, "Invalid percent value, please enter a valid number"); err != nil {
+			if _, err := secondTelegramClient.SendMessage(recipient, i18n.T(lang, i18n.InvalidPercent)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			}
 			return
@@ -175,7 +757,7 @@ func MessageHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.
 
 		chatID := m.Sender.ID
 		recipient := &tele.User{ID: chatID}
-		if _, err := secondTelegramClient.SendMessage(recipient, "The percentage expected for the pump has been changed"); err != nil {
+		if _, err := secondTelegramClient.SendMessage(recipient, i18n.T(lang, i18n.PumpPercentChanged)); err != nil {
 			log.Printf("Error sending message: %v", err)
 		} else {
 			log.Printf("Sent message to chat ID %d: %s", chatID, "The percentage expected for the pump has been changed")
@@ -188,7 +770,7 @@ func MessageHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.
 
 			chatID := m.Sender.ID
 			recipient := &tele.User{ID: chatID}
-			if _, err := secondTelegramClient.SendMessage(recipient, "Invalid wait time value, please enter a valid number"); err != nil {
+			if _, err := secondTelegramClient.SendMessage(recipient, i18n.T(lang, i18n.InvalidWaitTime)); err != nil {
 				log.Printf("Error sending message: %v", err)
 			}
 			return
@@ -200,7 +782,7 @@ func MessageHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.
 
 		chatID := m.Sender.ID
 		recipient := &tele.User{ID: chatID}
-		if _, err := secondTelegramClient.SendMessage(recipient, "The wait time for coin pumping has been changed"); err != nil {
+		if _, err := secondTelegramClient.SendMessage(recipient, i18n.T(lang, i18n.WaitTimeChanged)); err != nil {
 			log.Printf("Error sending message: %v", err)
 		} else {
 			log.Printf("Sent message to chat ID %d: %s", chatID, "The wait time for coin pumping has been changed")
@@ -208,6 +790,375 @@ func MessageHandlerSecondClient(m *tele.Message, secondTelegramClient *telegram.
 	}
 }
 
+func deliveryTarget(m *tele.Message, telegramClient *telegram.Client) (*tele.Chat, int, error) {
+	chat := m.Chat
+	threadID := m.ThreadID
+	if m.Payload != "" {
+		var err error
+		chat, err = telegramClient.ChatByUsername(m.Payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		threadID = 0
+	}
+
+	if err := telegramClient.VerifyDeliveryTarget(chat, m.Sender.ID); err != nil {
+		return nil, 0, err
+	}
+	return chat, threadID, nil
+}
+
+func confirmDeliveryTarget(telegramClient *telegram.Client, m *tele.Message, lang i18n.Language, chat *tele.Chat, threadID int, msg string) {
+	if _, err := telegramClient.SendMessage(chat, msg, &tele.SendOptions{ThreadID: threadID}); err != nil {
+		log.Printf("Error sending message: %v", err)
+	}
+	if chat.ID != m.Chat.ID {
+		name := chat.Title
+		if chat.Username != "" {
+			name = "@" + chat.Username
+		}
+		replyInChat(telegramClient, m, i18n.T(lang, i18n.DestinationChanged, name))
+	}
+}
+
+func deliveryTargetError(lang i18n.Language, err error) string {
+	switch {
+	case errors.Is(err, telegram.ErrNotChatAdmin):
+		return i18n.T(lang, i18n.NotChatAdmin)
+	case errors.Is(err, telegram.ErrBotCannotPost):
+		return i18n.T(lang, i18n.BotCannotPost)
+	default:
+		return err.Error()
+	}
+}
+
+func replyInChat(telegramClient *telegram.Client, m *tele.Message, msg string) {
+	if _, err := telegramClient.SendMessage(m.Chat, msg, &tele.SendOptions{ThreadID: m.ThreadID}); err != nil {
+		log.Printf("Error sending message: %v", err)
+	}
+}
+
+// restoreUser loads the saved settings of a user who just signed in.
+func restoreUser(db database.Database, usr *user.User) {
+	restoreLiveBoard(db, usr)
+	restoreLanguage(db, usr)
+	restoreRules(db, usr)
+	restoreQuietHours(db, usr)
+	restoreExchanges(db, usr)
+	restoreFutures(db, usr)
+	restoreLiquidity(db, usr)
+	restoreWhales(db, usr)
+	restoreListings(db, usr)
+	restoreIndicators(db, usr)
+}
+
+func restoreLiveBoard(db database.Database, usr *user.User) {
+	chatID, messageID, err := db.GetLiveBoard(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading live board message: %v", err)
+		return
+	}
+	// Boards saved before the flag was stored are on when they have a message.
+	enabled, err := db.GetLiveBoardEnabled(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading live board setting: %v", err)
+		return
+	}
+	if enabled || messageID != 0 {
+		usr.LiveBoard.Restore(chatID, messageID)
+	}
+}
+
+func restoreLanguage(db database.Database, usr *user.User) {
+	language, err := db.GetLanguage(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading language: %v", err)
+		return
+	}
+	if lang, ok := i18n.ParseLanguage(language); ok {
+		usr.SetLanguage(lang)
+	}
+}
+
+func restoreRules(db database.Database, usr *user.User) {
+	stored, err := db.GetRules(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading rules: %v", err)
+		return
+	}
+
+	list := make([]user.Rule, 0, len(stored))
+	for _, rule := range stored {
+		expr, err := rules.Parse(rule.Text)
+		if err != nil {
+			log.Printf("Skipping invalid stored rule %q: %v", rule.Text, err)
+			continue
+		}
+		list = append(list, user.Rule{ID: rule.ID, Text: rule.Text, Expr: expr})
+	}
+	usr.Rules.Replace(list)
+}
+
+func saveRules(db database.Database, usr *user.User) {
+	list := usr.Rules.List()
+	stored := make([]database.Rule, 0, len(list))
+	for _, rule := range list {
+		stored = append(stored, database.Rule{ID: rule.ID, Text: rule.Text})
+	}
+	if err := db.SaveRules(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving rules: %v", err)
+	}
+}
+
+func restoreQuietHours(db database.Database, usr *user.User) {
+	stored, err := db.GetQuietHours(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading quiet hours: %v", err)
+		return
+	}
+	if stored == nil {
+		return
+	}
+
+	usr.QuietHours.SetUrgentPercent(stored.UrgentPercent)
+	if stored.Schedule == "" {
+		return
+	}
+	schedule, err := quiethours.Parse(stored.Schedule)
+	if err != nil {
+		log.Printf("Skipping invalid stored quiet hours %q: %v", stored.Schedule, err)
+		return
+	}
+	usr.QuietHours.SetSchedule(schedule)
+}
+
+func saveQuietHours(db database.Database, usr *user.User) {
+	stored := database.QuietHours{UrgentPercent: usr.QuietHours.GetUrgentPercent()}
+	if schedule := usr.QuietHours.GetSchedule(); schedule != nil {
+		stored.Schedule = schedule.String()
+	}
+	if err := db.SaveQuietHours(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving quiet hours: %v", err)
+	}
+}
+
+func restoreExchanges(db database.Database, usr *user.User) {
+	stored, err := db.GetExchanges(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading exchanges: %v", err)
+		return
+	}
+
+	var names []string
+	for _, name := range stored {
+		if name, ok := venue.Parse(name); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		usr.Exchanges.Set(names)
+	}
+}
+
+func restoreFutures(db database.Database, usr *user.User) {
+	stored, err := db.GetFutures(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading futures settings: %v", err)
+		return
+	}
+	if stored == nil {
+		return
+	}
+
+	usr.Futures.SetEnabled(stored.Enabled)
+	if stored.OpenInterestPercent > 0 && stored.OpenInterestWindow > 0 && stored.FundingPercent > 0 {
+		usr.Futures.SetSettings(futures.Settings{
+			OpenInterestPercent: stored.OpenInterestPercent,
+			OpenInterestWindow:  stored.OpenInterestWindow,
+			FundingPercent:      stored.FundingPercent,
+		})
+	}
+}
+
+func saveFutures(db database.Database, usr *user.User) {
+	settings := usr.Futures.GetSettings()
+	stored := database.Futures{
+		Enabled:             usr.Futures.IsEnabled(),
+		OpenInterestPercent: settings.OpenInterestPercent,
+		OpenInterestWindow:  settings.OpenInterestWindow,
+		FundingPercent:      settings.FundingPercent,
+	}
+	if err := db.SaveFutures(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving futures settings: %v", err)
+	}
+}
+
+func restoreLiquidity(db database.Database, usr *user.User) {
+	stored, err := db.GetLiquidity(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading liquidity settings: %v", err)
+		return
+	}
+	if stored == nil {
+		return
+	}
+
+	if stored.Band > 0 && stored.Band < 100 {
+		usr.Liquidity.SetBand(stored.Band)
+	}
+	if stored.MinDepth >= 0 {
+		usr.Liquidity.SetMinDepth(stored.MinDepth)
+	}
+}
+
+func saveLiquidity(db database.Database, usr *user.User) {
+	stored := database.Liquidity{Band: usr.Liquidity.GetBand(), MinDepth: usr.Liquidity.GetMinDepth()}
+	if err := db.SaveLiquidity(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving liquidity settings: %v", err)
+	}
+}
+
+func restoreWhales(db database.Database, usr *user.User) {
+	stored, err := db.GetWhales(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading whale settings: %v", err)
+		return
+	}
+	if stored == nil {
+		return
+	}
+
+	usr.Whales.SetEnabled(stored.Enabled)
+	if stored.MinNotional >= 0 && stored.BurstNotional >= 0 && stored.MinNotional+stored.BurstNotional > 0 && stored.BurstWindow > 0 {
+		usr.Whales.SetSettings(user.WhaleSettings{
+			MinNotional:   stored.MinNotional,
+			BurstNotional: stored.BurstNotional,
+			BurstWindow:   stored.BurstWindow,
+		})
+	}
+}
+
+func saveWhales(db database.Database, usr *user.User) {
+	settings := usr.Whales.GetSettings()
+	stored := database.Whales{
+		Enabled:       usr.Whales.IsEnabled(),
+		MinNotional:   settings.MinNotional,
+		BurstNotional: settings.BurstNotional,
+		BurstWindow:   settings.BurstWindow,
+	}
+	if err := db.SaveWhales(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving whale settings: %v", err)
+	}
+}
+
+func restoreListings(db database.Database, usr *user.User) {
+	stored, err := db.GetListings(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading listings setting: %v", err)
+		return
+	}
+	if stored != nil {
+		usr.Listings.SetEnabled(stored.Enabled)
+	}
+}
+
+func saveListings(db database.Database, usr *user.User) {
+	if err := db.SaveListings(usr.GetEmail(), database.Listings{Enabled: usr.Listings.IsEnabled()}); err != nil {
+		log.Printf("Error saving listings setting: %v", err)
+	}
+}
+
+func restoreIndicators(db database.Database, usr *user.User) {
+	stored, err := db.GetIndicators(usr.GetEmail())
+	if err != nil {
+		log.Printf("Error loading indicators: %v", err)
+		return
+	}
+
+	list := make([]user.IndicatorSubscription, 0, len(stored))
+	for _, indicator := range stored {
+		signal, err := indicators.ParseSignal(strings.Fields(indicator.Condition))
+		if err != nil {
+			log.Printf("Skipping invalid stored indicator %q: %v", indicator.Condition, err)
+			continue
+		}
+		list = append(list, user.IndicatorSubscription{ID: indicator.ID, Symbol: indicator.Symbol, Signal: signal})
+	}
+	usr.Indicators.Replace(list)
+}
+
+func saveIndicators(db database.Database, usr *user.User) {
+	list := usr.Indicators.List()
+	stored := make([]database.Indicator, 0, len(list))
+	for _, subscription := range list {
+		stored = append(stored, database.Indicator{ID: subscription.ID, Symbol: subscription.Symbol, Condition: subscription.Signal.Condition()})
+	}
+	if err := db.SaveIndicators(usr.GetEmail(), stored); err != nil {
+		log.Printf("Error saving indicators: %v", err)
+	}
+}
+
+func launchMessage(lang i18n.Language, telegramClient *telegram.Client, secondTelegramClient *telegram.Client) string {
+	if telegramClient == secondTelegramClient {
+		return i18n.T(lang, i18n.TrackingLaunchedSingle)
+	}
+	return i18n.T(lang, i18n.TrackingLaunched, secondTelegramClient.Bot().Me.Username)
+}
+
+func rearmSettings(lang i18n.Language, usr *user.User, key i18n.Key) string {
+	steps := i18n.T(lang, i18n.RearmNone)
+	if values := usr.PumpSettings.GetSteps(); len(values) > 0 {
+		formatted := make([]string, 0, len(values))
+		for _, value := range values {
+			formatted = append(formatted, i18n.FormatPercent(lang, value, false))
+		}
+		steps = strings.Join(formatted, ", ")
+	}
+
+	retrace := i18n.T(lang, i18n.RearmNone)
+	if value := usr.PumpSettings.GetRetrace(); value > 0 {
+		retrace = i18n.FormatPercent(lang, value, false)
+	}
+
+	cooldown := i18n.FormatNumber(lang, usr.PumpSettings.GetCooldown().Minutes(), 0)
+	return i18n.T(lang, key, cooldown, steps, retrace)
+}
+
+func liquiditySettings(lang i18n.Language, usr *user.User, key i18n.Key) string {
+	minDepth := i18n.T(lang, i18n.LiquidityOff)
+	if value := usr.Liquidity.GetMinDepth(); value > 0 {
+		minDepth = i18n.FormatNumber(lang, value, 0) + " USDT"
+	}
+	return i18n.T(lang, key, i18n.FormatPercent(lang, usr.Liquidity.GetBand(), false), minDepth)
+}
+
+func whaleSettings(lang i18n.Language, settings user.WhaleSettings) []interface{} {
+	single := i18n.T(lang, i18n.WhalesOff)
+	if settings.MinNotional > 0 {
+		single = "≥ " + i18n.FormatNumber(lang, settings.MinNotional, 0) + " USDT"
+	}
+	burst := i18n.T(lang, i18n.WhalesOff)
+	if settings.BurstNotional > 0 {
+		burst = fmt.Sprintf("≥ %s USDT / %s s", i18n.FormatNumber(lang, settings.BurstNotional, 0), i18n.FormatNumber(lang, settings.BurstWindow.Seconds(), 0))
+	}
+	return []interface{}{single, burst}
+}
+
+func futuresSettings(lang i18n.Language, settings futures.Settings) []interface{} {
+	return []interface{}{
+		i18n.FormatPercent(lang, settings.OpenInterestPercent, false),
+		i18n.FormatNumber(lang, settings.OpenInterestWindow.Minutes(), 0),
+		i18n.FormatPercent(lang, settings.FundingPercent, false),
+	}
+}
+
+func indicatorSymbol(lang i18n.Language, symbol string) string {
+	if symbol == "" {
+		return i18n.T(lang, i18n.IndicatorAllSymbols)
+	}
+	return symbol
+}
+
 func sendMessage(telegramClient *telegram.Client, chatID int64, msg string) {
 	recipient := &tele.User{ID: chatID}
 	if _, err := telegramClient.SendMessage(recipient, msg); err != nil {
@@ -0,0 +1,29 @@
+package botcommands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{"250000", 250000, true},
+		{"250 000", 250000, true},
+		{"1 000 000", 1000000, true},
+		{"1 000,5", 1000.5, true},
+		{"100,000", 100000, true},
+		{"250000 10", 0, false},
+		{"250 10", 0, false},
+		{"1000 000", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseAmount(strings.Fields(tt.text))
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseAmount(%q) = %v, %v, want %v", tt.text, got, err, tt.want)
+		}
+	}
+}
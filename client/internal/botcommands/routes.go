@@ -0,0 +1,269 @@
+package botcommands
+
+import (
+	"github.com/agopankov/imPulse/client/internal/cancelfuncs"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	tele "gopkg.in/telebot.v3"
+	"log"
+	"time"
+)
+
+func NewDefaultUser() *user.User {
+	usr := user.NewUser()
+	usr.ChangePercent24.SetPercent(20)
+	usr.PumpSettings.SetPumpPercent(5)
+	usr.PumpSettings.SetWaitTime(15 * time.Minute)
+	usr.QuietHours.SetUrgentPercent(15)
+	return usr
+}
+
+// RegisterHandlers wires every command onto the bots. Passing the same client
+// twice runs the single-bot mode, where the first bot also takes the second
+// bot's commands.
+func RegisterHandlers(telegramClient *telegram.Client, secondTelegramClient *telegram.Client, userManager *user.UserManager, cancelFuncs *cancelfuncs.CancelFuncs, binanceClient proto.BinanceServiceClient, futuresClient proto.FuturesServiceClient, postmarkToken string) {
+	singleBotMode := telegramClient == secondTelegramClient
+
+	telegramClient.HandleCommand("/start", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			usr = NewDefaultUser()
+			usr.SetLanguage(i18n.FromLanguageCode(m.Sender.LanguageCode))
+			userManager.AddUser(m.Sender.ID, usr)
+		}
+
+		usr.FirstChatID = m.Sender.ID
+		if singleBotMode {
+			usr.SecondChatID = m.Sender.ID
+		}
+		StartCommandHandlerFirstClient(m, telegramClient, usr)
+	})
+	telegramClient.HandleCommand("/stop", func(m *tele.Message) {
+		StopCommandHandler(m, cancelFuncs)
+	})
+	telegramClient.HandleCommand("/change24percent", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		Change24PercentCommandHandler(m, telegramClient, usr)
+	})
+	telegramClient.HandleCommand("/template", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		TemplateCommandHandler(m, telegramClient, usr)
+	})
+	telegramClient.HandleCommand("/language", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		LanguageCommandHandler(m, telegramClient, usr, userManager)
+	})
+	telegramClient.HandleCommand("/quiet", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		QuietCommandHandler(m, telegramClient, usr, userManager)
+	})
+	telegramClient.HandleCommand("/urgent", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		UrgentCommandHandler(m, telegramClient, usr, userManager)
+	})
+	telegramClient.HandleCommand("/exchanges", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		ExchangesCommandHandler(m, telegramClient, usr, userManager)
+	})
+	telegramClient.HandleCommand("/liveboard", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		LiveBoardCommandHandler(m, telegramClient, usr, userManager)
+	})
+	telegramClient.HandleCommand("/listchat", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		ListChatCommandHandler(m, telegramClient, usr)
+	})
+
+	if !singleBotMode {
+		secondTelegramClient.HandleCommand("/start", func(m *tele.Message) {
+			usr, ok := userManager.GetUser(m.Sender.ID)
+			if !ok {
+				usr = NewDefaultUser()
+				usr.SetLanguage(i18n.FromLanguageCode(m.Sender.LanguageCode))
+				userManager.AddUser(m.Sender.ID, usr)
+			}
+
+			usr.SecondChatID = m.Sender.ID
+			StartCommandHandlerSecondClient(m, secondTelegramClient, usr)
+		})
+	}
+	secondTelegramClient.HandleCommand("/signalchat", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		SignalChatCommandHandler(m, secondTelegramClient, usr)
+	})
+	secondTelegramClient.HandleCommand("/setwaittime", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		SetWaitTimeCommandHandler(m, secondTelegramClient, usr)
+	})
+	secondTelegramClient.HandleCommand("/baseline", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		BaselineCommandHandler(m, secondTelegramClient, usr)
+	})
+	secondTelegramClient.HandleCommand("/indicator", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		IndicatorCommandHandler(m, secondTelegramClient, usr, binanceClient, userManager)
+	})
+	secondTelegramClient.HandleCommand("/rule", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		RuleCommandHandler(m, secondTelegramClient, usr, userManager)
+	})
+	secondTelegramClient.HandleCommand("/listings", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		ListingsCommandHandler(m, secondTelegramClient, usr, userManager)
+	})
+	secondTelegramClient.HandleCommand("/whales", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		WhalesCommandHandler(m, secondTelegramClient, usr, userManager)
+	})
+	secondTelegramClient.HandleCommand("/liquidity", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		LiquidityCommandHandler(m, secondTelegramClient, usr, userManager)
+	})
+	secondTelegramClient.HandleCommand("/futures", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		FuturesCommandHandler(m, secondTelegramClient, usr, userManager)
+	})
+	secondTelegramClient.HandleCommand("/rearm", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		RearmCommandHandler(m, secondTelegramClient, usr)
+	})
+	secondTelegramClient.HandleCommand("/setpumppercent", func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		SetPumpPercentCommandHandler(m, secondTelegramClient, usr)
+	})
+
+	if singleBotMode {
+		telegramClient.HandleOnMessage(func(m *tele.Message) {
+			usr, ok := userManager.GetUser(m.Sender.ID)
+			if !ok {
+				log.Printf("Unknown user with ID %d", m.Sender.ID)
+				return
+			}
+
+			switch usr.GetState() {
+			case user.StateAwaitingPumpPercent, user.StateAwaitingWaitTime:
+				MessageHandlerSecondClient(m, telegramClient, usr)
+			default:
+				MessageHandlerFirstClient(m, telegramClient, telegramClient, cancelFuncs, usr, binanceClient, futuresClient, userManager, postmarkToken)
+			}
+		})
+		return
+	}
+
+	telegramClient.HandleOnMessage(func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		MessageHandlerFirstClient(m, telegramClient, secondTelegramClient, cancelFuncs, usr, binanceClient, futuresClient, userManager, postmarkToken)
+	})
+
+	secondTelegramClient.HandleOnMessage(func(m *tele.Message) {
+		usr, ok := userManager.GetUser(m.Sender.ID)
+		if !ok {
+			log.Printf("Unknown user with ID %d", m.Sender.ID)
+			return
+		}
+
+		MessageHandlerSecondClient(m, secondTelegramClient, usr)
+	})
+}
@@ -0,0 +1,103 @@
+package botflow
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/server/pkg/fakebinance"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log.SetOutput(io.Discard)
+	}
+	os.Exit(m.Run())
+}
+
+func TestScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("bot flows take several seconds each")
+	}
+
+	number := func(value float64, decimals int) string {
+		return i18n.FormatNumber(i18n.English, value, decimals)
+	}
+
+	scenarios := []Scenario{
+		{Name: "onboarding", Steps: []Step{
+			Say(FirstBotToken, "/start"),
+			ExpectText(FirstBotToken, i18n.EnterEmail),
+			Say(FirstBotToken, "not an email"),
+			ExpectText(FirstBotToken, i18n.InvalidEmail),
+			Say(FirstBotToken, "trader@example.com"),
+			ExpectText(FirstBotToken, i18n.VerificationCodeSent),
+			Say(FirstBotToken, "wrong"),
+			ExpectText(FirstBotToken, i18n.VerificationFailed),
+			SayCode("trader@example.com"),
+			Expect(FirstBotToken, "Tracking service launched"),
+			Advance(),
+			Expect(FirstBotToken, "PEPEUSDT"),
+		}},
+		{Name: "settings", Steps: append(Onboard("settings@example.com"),
+			Say(FirstBotToken, "/change24percent"),
+			ExpectText(FirstBotToken, i18n.EnterChangePercent, number(20, 2)),
+			Say(FirstBotToken, "30"),
+			ExpectText(FirstBotToken, i18n.ChangePercentChanged),
+			Say(FirstBotToken, "/change24percent"),
+			ExpectText(FirstBotToken, i18n.EnterChangePercent, number(30, 2)),
+			Say(FirstBotToken, "25"),
+			ExpectText(FirstBotToken, i18n.ChangePercentChanged),
+			Say(SecondBotToken, "/start"),
+			ExpectText(SecondBotToken, i18n.SecondBotLaunched),
+			Say(SecondBotToken, "/setpumppercent"),
+			ExpectText(SecondBotToken, i18n.EnterPumpPercent, number(5, 2)),
+			Say(SecondBotToken, "7"),
+			ExpectText(SecondBotToken, i18n.PumpPercentChanged),
+			Say(SecondBotToken, "/setwaittime"),
+			ExpectText(SecondBotToken, i18n.EnterWaitTime, number(15, 0)),
+			Say(SecondBotToken, "soon"),
+			ExpectText(SecondBotToken, i18n.InvalidWaitTime),
+			Say(SecondBotToken, "10"),
+			ExpectText(SecondBotToken, i18n.WaitTimeChanged),
+		)},
+		{Name: "stop", Steps: append(Onboard("stop@example.com"),
+			Say(FirstBotToken, "/stop"),
+			ExpectNothing(FirstBotToken, 2*time.Second),
+			Advance(),
+			ExpectNothing(FirstBotToken, 8*time.Second),
+		)},
+	}
+
+	for i, scenario := range scenarios {
+		i, scenario := i, scenario
+		t.Run(scenario.Name, func(t *testing.T) {
+			t.Parallel()
+			if err := Run(testScript(), scenario, int64(100+i)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// testScript is a market where PEPEUSDT crosses the default 20% 24h change
+// on the second frame.
+func testScript() *fakebinance.Script {
+	frame := func(pepeChange float64) fakebinance.Frame {
+		return fakebinance.Frame{
+			Tickers: []fakebinance.Ticker{
+				{Symbol: "BTCUSDT", Price: 36500, ChangePercent: 1.2, QuoteVolume: 766500000},
+				{Symbol: "PEPEUSDT", Price: 0.0000012 * (1 + pepeChange/100), ChangePercent: pepeChange, QuoteVolume: 12000000},
+			},
+		}
+	}
+
+	return &fakebinance.Script{
+		Frames: []fakebinance.Frame{frame(12), frame(24), frame(31)},
+	}
+}
@@ -0,0 +1,155 @@
+package botflow
+
+import (
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/botcommands"
+	"github.com/agopankov/imPulse/client/internal/cancelfuncs"
+	"github.com/agopankov/imPulse/client/internal/database"
+	"github.com/agopankov/imPulse/client/internal/faketelegram"
+	imgrpc "github.com/agopankov/imPulse/client/internal/grpc"
+	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/server/pkg/fakebinance"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"google.golang.org/grpc"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	FirstBotToken  = "1000:first"
+	SecondBotToken = "2000:second"
+)
+
+// Harness runs the real bot handlers against faketelegram, a MemoryDB and a
+// gRPC server backed by fakebinance, all inside the current process.
+type Harness struct {
+	Telegram *faketelegram.Server
+	Binance  *fakebinance.Server
+	DB       *database.MemoryDB
+	Timeout  time.Duration
+
+	first      *telegram.Client
+	second     *telegram.Client
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+}
+
+func Start(script *fakebinance.Script) (*Harness, error) {
+	h := &Harness{
+		Telegram: faketelegram.New(),
+		DB:       database.NewMemoryDB(),
+		Timeout:  15 * time.Second,
+	}
+
+	if err := h.Telegram.Start("127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+
+	binance, err := fakebinance.New(script)
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	h.Binance = binance
+	if err := h.Binance.Start("127.0.0.1:0"); err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	binanceServer := grpcbinance.NewBinanceServiceServer("", "")
+	binanceServer.SetBaseURL(h.Binance.URL())
+	binanceServer.SetStreamURL(h.Binance.StreamURL() + "/ws")
+	h.grpcServer = grpc.NewServer()
+	proto.RegisterBinanceServiceServer(h.grpcServer, binanceServer)
+	go func() {
+		_ = h.grpcServer.Serve(listener)
+	}()
+
+	h.conn, err = imgrpc.NewGRPCConnection(listener.Addr().String())
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	h.first, err = telegram.NewClientWithAPI(FirstBotToken, h.Telegram.URL())
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	h.second, err = telegram.NewClientWithAPI(SecondBotToken, h.Telegram.URL())
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	userManager := user.NewUserManagerWithDB(h.DB)
+	botcommands.RegisterHandlers(h.first, h.second, userManager, cancelfuncs.NewCancelFuncs(), proto.NewBinanceServiceClient(h.conn), proto.NewFuturesServiceClient(h.conn), "")
+
+	go h.first.Start()
+	go h.second.Start()
+	return h, nil
+}
+
+func (h *Harness) Close() {
+	if h.first != nil {
+		h.first.Stop()
+	}
+	if h.second != nil {
+		h.second.Stop()
+	}
+	if h.conn != nil {
+		_ = h.conn.Close()
+	}
+	if h.grpcServer != nil {
+		h.grpcServer.Stop()
+	}
+	if h.Binance != nil {
+		_ = h.Binance.Close()
+	}
+	_ = h.Telegram.Close()
+}
+
+// Say sends text from the user to the bot with the given token.
+func (h *Harness) Say(token string, userID int64, text string) {
+	h.Telegram.SendText(token, userID, text)
+}
+
+// Expect waits for the next reply in the user's chat and checks that it
+// contains want.
+func (h *Harness) Expect(token string, userID int64, want string) (faketelegram.Message, error) {
+	message, err := h.Telegram.Next(token, userID, h.Timeout)
+	if err != nil {
+		return message, fmt.Errorf("%s: waiting for %q: %w", botName(token), want, err)
+	}
+	if !strings.Contains(message.Text, want) {
+		return message, fmt.Errorf("%s: got %q, want it to contain %q", botName(token), message.Text, want)
+	}
+	return message, nil
+}
+
+// ExpectNothing fails if the bot writes to the user's chat within wait.
+func (h *Harness) ExpectNothing(token string, userID int64, wait time.Duration) error {
+	message, err := h.Telegram.Next(token, userID, wait)
+	if err == faketelegram.ErrTimeout {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%s: got unexpected %q", botName(token), message.Text)
+}
+
+func botName(token string) string {
+	if token == SecondBotToken {
+		return "second bot"
+	}
+	return "first bot"
+}
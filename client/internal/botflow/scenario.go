@@ -0,0 +1,106 @@
+package botflow
+
+import (
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/server/pkg/fakebinance"
+	"time"
+)
+
+type Step struct {
+	Name string
+	Run  func(h *Harness, userID int64) error
+}
+
+type Scenario struct {
+	Name  string
+	Steps []Step
+}
+
+// Run plays the scenario for one user on a fresh harness.
+func Run(script *fakebinance.Script, scenario Scenario, userID int64) error {
+	h, err := Start(script)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	for i, step := range scenario.Steps {
+		if err := step.Run(h, userID); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Name, err)
+		}
+	}
+	return nil
+}
+
+func Say(token string, text string) Step {
+	return Step{
+		Name: fmt.Sprintf("say %q to %s", text, botName(token)),
+		Run: func(h *Harness, userID int64) error {
+			h.Say(token, userID, text)
+			return nil
+		},
+	}
+}
+
+func Expect(token string, want string) Step {
+	return Step{
+		Name: fmt.Sprintf("expect %q from %s", want, botName(token)),
+		Run: func(h *Harness, userID int64) error {
+			_, err := h.Expect(token, userID, want)
+			return err
+		},
+	}
+}
+
+func ExpectText(token string, key i18n.Key, args ...interface{}) Step {
+	return Expect(token, i18n.T(i18n.English, key, args...))
+}
+
+func ExpectNothing(token string, wait time.Duration) Step {
+	return Step{
+		Name: fmt.Sprintf("expect silence from %s for %s", botName(token), wait),
+		Run: func(h *Harness, userID int64) error {
+			return h.ExpectNothing(token, userID, wait)
+		},
+	}
+}
+
+// SayCode replies with the verification code the MemoryDB issued for email.
+func SayCode(email string) Step {
+	return Step{
+		Name: "say the verification code",
+		Run: func(h *Harness, userID int64) error {
+			code := h.DB.Code(email)
+			if code == "" {
+				return fmt.Errorf("no verification code issued for %s", email)
+			}
+			h.Say(FirstBotToken, userID, code)
+			return nil
+		},
+	}
+}
+
+// Advance moves fakebinance to its next market frame.
+func Advance() Step {
+	return Step{
+		Name: "advance the market",
+		Run: func(h *Harness, _ int64) error {
+			if !h.Binance.Advance() {
+				return fmt.Errorf("script has no frame after %d", h.Binance.Frame())
+			}
+			return nil
+		},
+	}
+}
+
+func Onboard(email string) []Step {
+	return []Step{
+		Say(FirstBotToken, "/start"),
+		ExpectText(FirstBotToken, i18n.EnterEmail),
+		Say(FirstBotToken, email),
+		ExpectText(FirstBotToken, i18n.VerificationCodeSent),
+		SayCode(email),
+		Expect(FirstBotToken, "Tracking service launched"),
+	}
+}
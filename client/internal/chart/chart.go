@@ -0,0 +1,143 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+)
+
+const (
+	width   = 640
+	height  = 360
+	padding = 16
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0x13, G: 0x17, B: 0x22, A: 0xff}
+	gridColor       = color.RGBA{R: 0x2a, G: 0x2e, B: 0x39, A: 0xff}
+	upColor         = color.RGBA{R: 0x26, G: 0xa6, B: 0x9a, A: 0xff}
+	downColor       = color.RGBA{R: 0xef, G: 0x53, B: 0x50, A: 0xff}
+	baselineColor   = color.RGBA{R: 0xf0, G: 0xb9, B: 0x0b, A: 0xff}
+	markerColor     = color.RGBA{R: 0x78, G: 0x7b, B: 0x86, A: 0xff}
+)
+
+var ErrNoCandles = errors.New("no candles to render")
+
+type Candle struct {
+	Time  time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+func RenderPNG(candles []Candle, baseline float64, marker time.Time) ([]byte, error) {
+	if len(candles) == 0 {
+		return nil, ErrNoCandles
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	plot := image.Rect(padding, padding, width-padding, height-padding)
+	low, high := priceRange(candles, baseline)
+	y := func(price float64) int {
+		return plot.Min.Y + int((high-price)/(high-low)*float64(plot.Dy()-1))
+	}
+
+	for i := 0; i <= 4; i++ {
+		gridY := plot.Min.Y + i*(plot.Dy()-1)/4
+		horizontalLine(img, plot.Min.X, plot.Max.X, gridY, gridColor, 1)
+	}
+
+	slot := float64(plot.Dx()) / float64(len(candles))
+	bodyWidth := int(slot * 0.6)
+	if bodyWidth < 1 {
+		bodyWidth = 1
+	}
+
+	for i, candle := range candles {
+		left := plot.Min.X + int(float64(i)*slot)
+		center := left + int(slot/2)
+
+		if !marker.IsZero() && !candle.Time.After(marker) && (i == len(candles)-1 || candles[i+1].Time.After(marker)) {
+			verticalLine(img, center, plot.Min.Y, plot.Max.Y, markerColor, 4)
+		}
+
+		candleColor := upColor
+		if candle.Close < candle.Open {
+			candleColor = downColor
+		}
+
+		verticalLine(img, center, y(candle.High), y(candle.Low), candleColor, 0)
+
+		top, bottom := y(candle.Open), y(candle.Close)
+		if top > bottom {
+			top, bottom = bottom, top
+		}
+		body := image.Rect(center-bodyWidth/2, top, center-bodyWidth/2+bodyWidth, bottom+1)
+		draw.Draw(img, body, &image.Uniform{C: candleColor}, image.Point{}, draw.Src)
+	}
+
+	if baseline > 0 {
+		horizontalLine(img, plot.Min.X, plot.Max.X, y(baseline), baselineColor, 6)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func priceRange(candles []Candle, baseline float64) (float64, float64) {
+	low, high := candles[0].Low, candles[0].High
+	for _, candle := range candles {
+		if candle.Low < low {
+			low = candle.Low
+		}
+		if candle.High > high {
+			high = candle.High
+		}
+	}
+	if baseline > 0 {
+		if baseline < low {
+			low = baseline
+		}
+		if baseline > high {
+			high = baseline
+		}
+	}
+
+	margin := (high - low) * 0.05
+	if margin == 0 {
+		margin = high * 0.01
+	}
+	if margin == 0 {
+		margin = 1
+	}
+	return low - margin, high + margin
+}
+
+func horizontalLine(img *image.RGBA, x1, x2, y int, c color.Color, dash int) {
+	for x := x1; x < x2; x++ {
+		if dash == 0 || (x/dash)%2 == 0 {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+func verticalLine(img *image.RGBA, x, y1, y2 int, c color.Color, dash int) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		if dash == 0 || (y/dash)%2 == 0 {
+			img.Set(x, y, c)
+		}
+	}
+}
@@ -5,11 +5,95 @@ import (
 )
 
 type Verification struct {
-	Email        string
-	Code         string
-	FirstBotID   int64
-	SecondBotID  int64
-	LastVerified time.Time
+	Email              string
+	Code               string
+	FirstBotID         int64
+	SecondBotID        int64
+	LastVerified       time.Time
+	LiveBoardChatID    int64
+	LiveBoardMessageID int
+	LiveBoardEnabled   bool
+	Language           string
+	Rules              []string
+	RuleIDs            []int
+	QuietHours         *QuietHours
+	Exchanges          []string
+	Futures            *Futures
+	Liquidity          *Liquidity
+	Whales             *Whales
+	Listings           *Listings
+	Indicators         []Indicator
+}
+
+// QuietHours is the stored /quiet and /urgent setup. Schedule is in the form
+// quiethours.Parse reads, empty when quiet hours are off.
+type QuietHours struct {
+	Schedule      string
+	UrgentPercent float64
+}
+
+// Futures is the stored /futures setup.
+type Futures struct {
+	Enabled             bool
+	OpenInterestPercent float64
+	OpenInterestWindow  time.Duration
+	FundingPercent      float64
+}
+
+// Liquidity is the stored /liquidity setup.
+type Liquidity struct {
+	Band     float64
+	MinDepth float64
+}
+
+// Whales is the stored /whales setup.
+type Whales struct {
+	Enabled       bool
+	MinNotional   float64
+	BurstNotional float64
+	BurstWindow   time.Duration
+}
+
+// Listings is the stored /listings setup.
+type Listings struct {
+	Enabled bool
+}
+
+// Indicator is a stored /indicator subscription. Symbol is empty for all
+// symbols, Condition is in the form indicators.ParseSignal reads.
+type Indicator struct {
+	ID        int
+	Symbol    string
+	Condition string
+}
+
+// Rule is a stored user rule. Rules saved before their IDs were stored come
+// back with ID 0.
+type Rule struct {
+	ID   int
+	Text string
+}
+
+func splitRules(rules []Rule) ([]string, []int) {
+	texts := make([]string, 0, len(rules))
+	ids := make([]int, 0, len(rules))
+	for _, rule := range rules {
+		texts = append(texts, rule.Text)
+		ids = append(ids, rule.ID)
+	}
+	return texts, ids
+}
+
+func (v Verification) rules() []Rule {
+	rules := make([]Rule, 0, len(v.Rules))
+	for i, text := range v.Rules {
+		rule := Rule{Text: text}
+		if i < len(v.RuleIDs) {
+			rule.ID = v.RuleIDs[i]
+		}
+		rules = append(rules, rule)
+	}
+	return rules
 }
 
 type Database interface {
@@ -17,4 +101,31 @@ type Database interface {
 	VerifyCode(emailAddress string, code string) bool
 	ShouldSendVerificationEmail(emailAddress string) bool
 	GetAllUsers() ([]Verification, error)
+	SaveLiveBoard(emailAddress string, chatID int64, messageID int) error
+	GetLiveBoard(emailAddress string) (int64, int, error)
+	SaveLiveBoardEnabled(emailAddress string, enabled bool) error
+	GetLiveBoardEnabled(emailAddress string) (bool, error)
+	SaveLanguage(emailAddress string, language string) error
+	GetLanguage(emailAddress string) (string, error)
+	SaveRules(emailAddress string, rules []Rule) error
+	GetRules(emailAddress string) ([]Rule, error)
+	SaveQuietHours(emailAddress string, quietHours QuietHours) error
+	// GetQuietHours is nil when nothing was saved yet.
+	GetQuietHours(emailAddress string) (*QuietHours, error)
+	SaveExchanges(emailAddress string, exchanges []string) error
+	GetExchanges(emailAddress string) ([]string, error)
+	SaveFutures(emailAddress string, futures Futures) error
+	// GetFutures is nil when nothing was saved yet.
+	GetFutures(emailAddress string) (*Futures, error)
+	SaveLiquidity(emailAddress string, liquidity Liquidity) error
+	// GetLiquidity is nil when nothing was saved yet.
+	GetLiquidity(emailAddress string) (*Liquidity, error)
+	SaveWhales(emailAddress string, whales Whales) error
+	// GetWhales is nil when nothing was saved yet.
+	GetWhales(emailAddress string) (*Whales, error)
+	SaveListings(emailAddress string, listings Listings) error
+	// GetListings is nil when nothing was saved yet.
+	GetListings(emailAddress string) (*Listings, error)
+	SaveIndicators(emailAddress string, indicators []Indicator) error
+	GetIndicators(emailAddress string) ([]Indicator, error)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"log"
+	"strconv"
 	"time"
 )
 
@@ -18,25 +19,32 @@ func (d *DynamoDB) SendVerificationEmail(emailAddress string, firstBotID int64,
 	verificationCode := emailverify.GenerateVerificationCode(6)
 
 	db := dynamodb.New(sess)
-	item := Verification{
-		Email:       emailAddress,
-		Code:        verificationCode,
-		FirstBotID:  firstBotID,
-		SecondBotID: secondBotID,
-	}
-	av, err := dynamodbattribute.MarshalMap(item)
-	if err != nil {
-		log.Fatalf("Got error marshalling map: %s", err)
-	}
-
-	input := &dynamodb.PutItemInput{
-		Item:      av,
+	// UpdateItem creates the item for a new user and leaves the saved
+	// settings of a returning one alone, PutItem would replace them all.
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":c": {
+				S: aws.String(verificationCode),
+			},
+			":f": {
+				N: aws.String(strconv.FormatInt(firstBotID, 10)),
+			},
+			":s": {
+				N: aws.String(strconv.FormatInt(secondBotID, 10)),
+			},
+		},
 		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+		UpdateExpression: aws.String("set Code = :c, FirstBotID = :f, SecondBotID = :s"),
 	}
 
-	_, err = db.PutItem(input)
+	_, err := db.UpdateItem(input)
 	if err != nil {
-		log.Fatalf("Got error calling PutItem: %s", err)
+		log.Fatalf("Got error calling UpdateItem: %s", err)
 	}
 
 	sender := emailsender.NewEmailSender(postmarkToken)
@@ -145,3 +153,279 @@ func (d *DynamoDB) GetAllUsers() ([]Verification, error) {
 
 	return users, nil
 }
+
+func (d *DynamoDB) SaveLiveBoard(emailAddress string, chatID int64, messageID int) error {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	_, err := db.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":c": {
+				N: aws.String(strconv.FormatInt(chatID, 10)),
+			},
+			":m": {
+				N: aws.String(strconv.Itoa(messageID)),
+			},
+		},
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+		UpdateExpression: aws.String("set LiveBoardChatID = :c, LiveBoardMessageID = :m"),
+	})
+	return err
+}
+
+func (d *DynamoDB) GetLiveBoard(emailAddress string) (int64, int, error) {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	result, err := db.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	item := Verification{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &item); err != nil {
+		return 0, 0, err
+	}
+
+	return item.LiveBoardChatID, item.LiveBoardMessageID, nil
+}
+
+func (d *DynamoDB) SaveLiveBoardEnabled(emailAddress string, enabled bool) error {
+	return d.set(emailAddress, "LiveBoardEnabled", enabled)
+}
+
+func (d *DynamoDB) GetLiveBoardEnabled(emailAddress string) (bool, error) {
+	item, err := d.get(emailAddress)
+	return item.LiveBoardEnabled, err
+}
+
+func (d *DynamoDB) SaveLanguage(emailAddress string, language string) error {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	_, err := db.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":l": {
+				S: aws.String(language),
+			},
+		},
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+		UpdateExpression: aws.String("set #l = :l"),
+		ExpressionAttributeNames: map[string]*string{
+			"#l": aws.String("Language"),
+		},
+	})
+	return err
+}
+
+func (d *DynamoDB) GetLanguage(emailAddress string) (string, error) {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	result, err := db.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	item := Verification{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &item); err != nil {
+		return "", err
+	}
+
+	return item.Language, nil
+}
+
+func (d *DynamoDB) SaveRules(emailAddress string, rules []Rule) error {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	texts, ids := splitRules(rules)
+	value, err := dynamodbattribute.Marshal(texts)
+	if err != nil {
+		return err
+	}
+	idsValue, err := dynamodbattribute.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": value,
+			":i": idsValue,
+		},
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+		UpdateExpression: aws.String("set #r = :r, #i = :i"),
+		ExpressionAttributeNames: map[string]*string{
+			"#r": aws.String("Rules"),
+			"#i": aws.String("RuleIDs"),
+		},
+	})
+	return err
+}
+
+func (d *DynamoDB) GetRules(emailAddress string) ([]Rule, error) {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	result, err := db.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := Verification{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, err
+	}
+
+	return item.rules(), nil
+}
+
+func (d *DynamoDB) SaveQuietHours(emailAddress string, quietHours QuietHours) error {
+	return d.set(emailAddress, "QuietHours", quietHours)
+}
+
+func (d *DynamoDB) GetQuietHours(emailAddress string) (*QuietHours, error) {
+	item, err := d.get(emailAddress)
+	return item.QuietHours, err
+}
+
+func (d *DynamoDB) SaveExchanges(emailAddress string, exchanges []string) error {
+	return d.set(emailAddress, "Exchanges", exchanges)
+}
+
+func (d *DynamoDB) GetExchanges(emailAddress string) ([]string, error) {
+	item, err := d.get(emailAddress)
+	return item.Exchanges, err
+}
+
+func (d *DynamoDB) SaveFutures(emailAddress string, futures Futures) error {
+	return d.set(emailAddress, "Futures", futures)
+}
+
+func (d *DynamoDB) GetFutures(emailAddress string) (*Futures, error) {
+	item, err := d.get(emailAddress)
+	return item.Futures, err
+}
+
+func (d *DynamoDB) SaveLiquidity(emailAddress string, liquidity Liquidity) error {
+	return d.set(emailAddress, "Liquidity", liquidity)
+}
+
+func (d *DynamoDB) GetLiquidity(emailAddress string) (*Liquidity, error) {
+	item, err := d.get(emailAddress)
+	return item.Liquidity, err
+}
+
+func (d *DynamoDB) SaveWhales(emailAddress string, whales Whales) error {
+	return d.set(emailAddress, "Whales", whales)
+}
+
+func (d *DynamoDB) GetWhales(emailAddress string) (*Whales, error) {
+	item, err := d.get(emailAddress)
+	return item.Whales, err
+}
+
+func (d *DynamoDB) SaveListings(emailAddress string, listings Listings) error {
+	return d.set(emailAddress, "Listings", listings)
+}
+
+func (d *DynamoDB) GetListings(emailAddress string) (*Listings, error) {
+	item, err := d.get(emailAddress)
+	return item.Listings, err
+}
+
+func (d *DynamoDB) SaveIndicators(emailAddress string, indicators []Indicator) error {
+	return d.set(emailAddress, "Indicators", indicators)
+}
+
+func (d *DynamoDB) GetIndicators(emailAddress string) ([]Indicator, error) {
+	item, err := d.get(emailAddress)
+	return item.Indicators, err
+}
+
+func (d *DynamoDB) set(emailAddress string, attribute string, value interface{}) error {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	marshalled, err := dynamodbattribute.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": marshalled,
+		},
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+		UpdateExpression: aws.String("set #a = :v"),
+		ExpressionAttributeNames: map[string]*string{
+			"#a": aws.String(attribute),
+		},
+	})
+	return err
+}
+
+func (d *DynamoDB) get(emailAddress string) (Verification, error) {
+	sess := sess()
+	db := dynamodb.New(sess)
+
+	result, err := db.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("users"),
+		Key: map[string]*dynamodb.AttributeValue{
+			"Email": {
+				S: aws.String(emailAddress),
+			},
+		},
+	})
+	if err != nil {
+		return Verification{}, err
+	}
+
+	item := Verification{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &item); err != nil {
+		return Verification{}, err
+	}
+	return item, nil
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"github.com/agopankov/imPulse/client/internal/emailverify"
+	"sync"
+	"time"
+)
+
+// MemoryDB keeps users in process memory and never sends email. It backs
+// local runs against fake APIs; the issued codes are read back with Code.
+type MemoryDB struct {
+	mu    sync.Mutex
+	users map[string]*Verification
+}
+
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{users: make(map[string]*Verification)}
+}
+
+func (m *MemoryDB) SendVerificationEmail(emailAddress string, firstBotID int64, secondBotID int64, _ string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.users[emailAddress]
+	if !ok {
+		item = &Verification{Email: emailAddress}
+		m.users[emailAddress] = item
+	}
+	item.Code = emailverify.GenerateVerificationCode(6)
+	item.FirstBotID = firstBotID
+	item.SecondBotID = secondBotID
+}
+
+func (m *MemoryDB) Code(emailAddress string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.users[emailAddress]
+	if !ok {
+		return ""
+	}
+	return item.Code
+}
+
+func (m *MemoryDB) VerifyCode(emailAddress string, code string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.users[emailAddress]
+	if !ok || item.Code != code {
+		return false
+	}
+	item.LastVerified = time.Now()
+	return true
+}
+
+func (m *MemoryDB) ShouldSendVerificationEmail(emailAddress string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.users[emailAddress]
+	if !ok {
+		return true
+	}
+	return item.LastVerified.IsZero() || time.Since(item.LastVerified) > 240*time.Hour
+}
+
+func (m *MemoryDB) GetAllUsers() ([]Verification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]Verification, 0, len(m.users))
+	for _, item := range m.users {
+		users = append(users, *item)
+	}
+	return users, nil
+}
+
+func (m *MemoryDB) SaveLiveBoard(emailAddress string, chatID int64, messageID int) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.LiveBoardChatID = chatID
+		item.LiveBoardMessageID = messageID
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetLiveBoard(emailAddress string) (int64, int, error) {
+	item := m.get(emailAddress)
+	return item.LiveBoardChatID, item.LiveBoardMessageID, nil
+}
+
+func (m *MemoryDB) SaveLiveBoardEnabled(emailAddress string, enabled bool) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.LiveBoardEnabled = enabled
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetLiveBoardEnabled(emailAddress string) (bool, error) {
+	return m.get(emailAddress).LiveBoardEnabled, nil
+}
+
+func (m *MemoryDB) SaveLanguage(emailAddress string, language string) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Language = language
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetLanguage(emailAddress string) (string, error) {
+	return m.get(emailAddress).Language, nil
+}
+
+func (m *MemoryDB) SaveRules(emailAddress string, rules []Rule) error {
+	texts, ids := splitRules(rules)
+	m.update(emailAddress, func(item *Verification) {
+		item.Rules = texts
+		item.RuleIDs = ids
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetRules(emailAddress string) ([]Rule, error) {
+	return m.get(emailAddress).rules(), nil
+}
+
+func (m *MemoryDB) SaveQuietHours(emailAddress string, quietHours QuietHours) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.QuietHours = &quietHours
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetQuietHours(emailAddress string) (*QuietHours, error) {
+	return m.get(emailAddress).QuietHours, nil
+}
+
+func (m *MemoryDB) SaveExchanges(emailAddress string, exchanges []string) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Exchanges = append([]string(nil), exchanges...)
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetExchanges(emailAddress string) ([]string, error) {
+	return m.get(emailAddress).Exchanges, nil
+}
+
+func (m *MemoryDB) SaveFutures(emailAddress string, futures Futures) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Futures = &futures
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetFutures(emailAddress string) (*Futures, error) {
+	return m.get(emailAddress).Futures, nil
+}
+
+func (m *MemoryDB) SaveLiquidity(emailAddress string, liquidity Liquidity) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Liquidity = &liquidity
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetLiquidity(emailAddress string) (*Liquidity, error) {
+	return m.get(emailAddress).Liquidity, nil
+}
+
+func (m *MemoryDB) SaveWhales(emailAddress string, whales Whales) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Whales = &whales
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetWhales(emailAddress string) (*Whales, error) {
+	return m.get(emailAddress).Whales, nil
+}
+
+func (m *MemoryDB) SaveListings(emailAddress string, listings Listings) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Listings = &listings
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetListings(emailAddress string) (*Listings, error) {
+	return m.get(emailAddress).Listings, nil
+}
+
+func (m *MemoryDB) SaveIndicators(emailAddress string, indicators []Indicator) error {
+	m.update(emailAddress, func(item *Verification) {
+		item.Indicators = append([]Indicator(nil), indicators...)
+	})
+	return nil
+}
+
+func (m *MemoryDB) GetIndicators(emailAddress string) ([]Indicator, error) {
+	return m.get(emailAddress).Indicators, nil
+}
+
+func (m *MemoryDB) get(emailAddress string) Verification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.users[emailAddress]
+	if !ok {
+		return Verification{}
+	}
+	return *item
+}
+
+func (m *MemoryDB) update(emailAddress string, fn func(item *Verification)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if item, ok := m.users[emailAddress]; ok {
+		fn(item)
+	}
+}
@@ -34,15 +34,11 @@ func (m *MongoDB) SendVerificationEmail(emailAddress string, firstBotID int64, s
 	verificationCode := emailverify.GenerateVerificationCode(6)
 
 	collection := m.client.Database("impulse").Collection("users")
-	item := Verification{
-		Email:       emailAddress,
-		Code:        verificationCode,
-		FirstBotID:  firstBotID,
-		SecondBotID: secondBotID,
-	}
-	_, err := collection.InsertOne(context.Background(), item)
+	// Upsert keeps one document per email and the settings saved in it.
+	update := bson.M{"$set": bson.M{"code": verificationCode, "firstbotid": firstBotID, "secondbotid": secondBotID}}
+	_, err := collection.UpdateOne(context.Background(), bson.M{"email": emailAddress}, update, options.Update().SetUpsert(true))
 	if err != nil {
-		log.Fatalf("Got error inserting item: %s", err)
+		log.Fatalf("Got error upserting item: %s", err)
 	}
 
 	sender := emailsender.NewEmailSender(postmarkToken)
@@ -116,3 +112,158 @@ func (m *MongoDB) GetAllUsers() ([]Verification, error) {
 
 	return users, nil
 }
+
+func (m *MongoDB) SaveLiveBoard(emailAddress string, chatID int64, messageID int) error {
+	collection := m.client.Database("impulse").Collection("users")
+
+	_, err := collection.UpdateOne(context.Background(), bson.M{"email": emailAddress}, bson.M{"$set": bson.M{"liveboardchatid": chatID, "liveboardmessageid": messageID}})
+	return err
+}
+
+func (m *MongoDB) GetLiveBoard(emailAddress string) (int64, int, error) {
+	collection := m.client.Database("impulse").Collection("users")
+
+	var item Verification
+	err := collection.FindOne(context.Background(), bson.M{"email": emailAddress}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	return item.LiveBoardChatID, item.LiveBoardMessageID, nil
+}
+
+func (m *MongoDB) SaveLiveBoardEnabled(emailAddress string, enabled bool) error {
+	return m.set(emailAddress, "liveboardenabled", enabled)
+}
+
+func (m *MongoDB) GetLiveBoardEnabled(emailAddress string) (bool, error) {
+	item, err := m.get(emailAddress)
+	return item.LiveBoardEnabled, err
+}
+
+func (m *MongoDB) SaveLanguage(emailAddress string, language string) error {
+	collection := m.client.Database("impulse").Collection("users")
+
+	_, err := collection.UpdateOne(context.Background(), bson.M{"email": emailAddress}, bson.M{"$set": bson.M{"language": language}})
+	return err
+}
+
+func (m *MongoDB) GetLanguage(emailAddress string) (string, error) {
+	collection := m.client.Database("impulse").Collection("users")
+
+	var item Verification
+	err := collection.FindOne(context.Background(), bson.M{"email": emailAddress}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return item.Language, nil
+}
+
+func (m *MongoDB) SaveRules(emailAddress string, rules []Rule) error {
+	collection := m.client.Database("impulse").Collection("users")
+
+	texts, ids := splitRules(rules)
+	_, err := collection.UpdateOne(context.Background(), bson.M{"email": emailAddress}, bson.M{"$set": bson.M{"rules": texts, "ruleids": ids}})
+	return err
+}
+
+func (m *MongoDB) GetRules(emailAddress string) ([]Rule, error) {
+	collection := m.client.Database("impulse").Collection("users")
+
+	var item Verification
+	err := collection.FindOne(context.Background(), bson.M{"email": emailAddress}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return item.rules(), nil
+}
+
+func (m *MongoDB) SaveQuietHours(emailAddress string, quietHours QuietHours) error {
+	return m.set(emailAddress, "quiethours", quietHours)
+}
+
+func (m *MongoDB) GetQuietHours(emailAddress string) (*QuietHours, error) {
+	item, err := m.get(emailAddress)
+	return item.QuietHours, err
+}
+
+func (m *MongoDB) SaveExchanges(emailAddress string, exchanges []string) error {
+	return m.set(emailAddress, "exchanges", exchanges)
+}
+
+func (m *MongoDB) GetExchanges(emailAddress string) ([]string, error) {
+	item, err := m.get(emailAddress)
+	return item.Exchanges, err
+}
+
+func (m *MongoDB) SaveFutures(emailAddress string, futures Futures) error {
+	return m.set(emailAddress, "futures", futures)
+}
+
+func (m *MongoDB) GetFutures(emailAddress string) (*Futures, error) {
+	item, err := m.get(emailAddress)
+	return item.Futures, err
+}
+
+func (m *MongoDB) SaveLiquidity(emailAddress string, liquidity Liquidity) error {
+	return m.set(emailAddress, "liquidity", liquidity)
+}
+
+func (m *MongoDB) GetLiquidity(emailAddress string) (*Liquidity, error) {
+	item, err := m.get(emailAddress)
+	return item.Liquidity, err
+}
+
+func (m *MongoDB) SaveWhales(emailAddress string, whales Whales) error {
+	return m.set(emailAddress, "whales", whales)
+}
+
+func (m *MongoDB) GetWhales(emailAddress string) (*Whales, error) {
+	item, err := m.get(emailAddress)
+	return item.Whales, err
+}
+
+func (m *MongoDB) SaveListings(emailAddress string, listings Listings) error {
+	return m.set(emailAddress, "listings", listings)
+}
+
+func (m *MongoDB) GetListings(emailAddress string) (*Listings, error) {
+	item, err := m.get(emailAddress)
+	return item.Listings, err
+}
+
+func (m *MongoDB) SaveIndicators(emailAddress string, indicators []Indicator) error {
+	return m.set(emailAddress, "indicators", indicators)
+}
+
+func (m *MongoDB) GetIndicators(emailAddress string) ([]Indicator, error) {
+	item, err := m.get(emailAddress)
+	return item.Indicators, err
+}
+
+func (m *MongoDB) set(emailAddress string, field string, value interface{}) error {
+	collection := m.client.Database("impulse").Collection("users")
+
+	_, err := collection.UpdateOne(context.Background(), bson.M{"email": emailAddress}, bson.M{"$set": bson.M{field: value}})
+	return err
+}
+
+// get is an empty record for an unknown address.
+func (m *MongoDB) get(emailAddress string) (Verification, error) {
+	collection := m.client.Database("impulse").Collection("users")
+
+	var item Verification
+	err := collection.FindOne(context.Background(), bson.M{"email": emailAddress}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return Verification{}, nil
+	}
+	return item, err
+}
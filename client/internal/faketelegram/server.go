@@ -0,0 +1,495 @@
+package faketelegram
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrTimeout = errors.New("timed out waiting for a bot message")
+
+// Message is something a bot sent or edited through the fake API.
+type Message struct {
+	ID       int
+	ChatID   int64
+	ThreadID int
+	Text     string
+	Photo    bool
+	Silent   bool
+	Edited   bool
+	Pinned   bool
+}
+
+type CallbackAnswer struct {
+	ID   string
+	Text string
+}
+
+// Server is a stand-in for api.telegram.org. Every bot token gets its own
+// update queue and message log, so one server can host both imPulse bots.
+type Server struct {
+	mu         sync.Mutex
+	changed    chan struct{}
+	bots       map[string]*bot
+	nextUser   int64
+	chats      map[string]int64
+	listener   net.Listener
+	httpServer *http.Server
+}
+
+type bot struct {
+	id         int64
+	username   string
+	updates    []map[string]interface{}
+	nextUpdate int
+	messages   []*Message
+	nextID     int
+	read       map[int64]int
+	answers    []CallbackAnswer
+}
+
+func New() *Server {
+	return &Server{
+		changed:  make(chan struct{}),
+		bots:     make(map[string]*bot),
+		chats:    make(map[string]int64),
+		nextUser: 1000,
+	}
+}
+
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: s.Handler()}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Fake Telegram server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (s *Server) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Close()
+}
+
+// URL is the value for tele.Settings.URL.
+func (s *Server) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(s.handle)
+}
+
+// SendText delivers a text message from a user to the bot, as if typed in a
+// private chat.
+func (s *Server) SendText(token string, from int64, text string) {
+	s.push(token, func(b *bot) map[string]interface{} {
+		b.nextID++
+		return map[string]interface{}{
+			"message": map[string]interface{}{
+				"message_id": b.nextID,
+				"date":       time.Now().Unix(),
+				"from":       user(from),
+				"chat":       map[string]interface{}{"id": from, "type": "private"},
+				"text":       text,
+			},
+		}
+	})
+}
+
+// PressButton delivers a callback query for an inline button under one of the
+// bot's messages.
+func (s *Server) PressButton(token string, from int64, messageID int, data string) string {
+	var id string
+	s.push(token, func(b *bot) map[string]interface{} {
+		id = strconv.Itoa(b.nextUpdate + 1)
+		return map[string]interface{}{
+			"callback_query": map[string]interface{}{
+				"id":   id,
+				"from": user(from),
+				"message": map[string]interface{}{
+					"message_id": messageID,
+					"chat":       map[string]interface{}{"id": from, "type": "private"},
+				},
+				"data": data,
+			},
+		}
+	})
+	return id
+}
+
+// AddChat makes a group or channel resolvable by its @username.
+func (s *Server) AddChat(username string, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chats[strings.TrimPrefix(username, "@")] = id
+}
+
+// Messages returns everything the bot has sent to the chat so far.
+func (s *Server) Messages(token string, chatID int64) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []Message
+	for _, message := range s.bot(token).messages {
+		if message.ChatID == chatID {
+			messages = append(messages, *message)
+		}
+	}
+	return messages
+}
+
+// Next returns the first message the bot sent to the chat that has not been
+// returned by Next before, waiting up to timeout for it to arrive.
+func (s *Server) Next(token string, chatID int64, timeout time.Duration) (Message, error) {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		b := s.bot(token)
+		for i := b.read[chatID]; i < len(b.messages); i++ {
+			if b.messages[i].ChatID == chatID {
+				b.read[chatID] = i + 1
+				message := *b.messages[i]
+				s.mu.Unlock()
+				return message, nil
+			}
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return Message{}, ErrTimeout
+		}
+	}
+}
+
+// Drain marks every message sent to the chat as read and returns them.
+func (s *Server) Drain(token string, chatID int64) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bot(token)
+	var messages []Message
+	for i := b.read[chatID]; i < len(b.messages); i++ {
+		if b.messages[i].ChatID == chatID {
+			messages = append(messages, *b.messages[i])
+		}
+	}
+	b.read[chatID] = len(b.messages)
+	return messages
+}
+
+func (s *Server) CallbackAnswers(token string) []CallbackAnswer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]CallbackAnswer(nil), s.bot(token).answers...)
+}
+
+func (s *Server) push(token string, update func(b *bot) map[string]interface{}) {
+	s.mu.Lock()
+	b := s.bot(token)
+	u := update(b)
+	b.nextUpdate++
+	u["update_id"] = b.nextUpdate
+	b.updates = append(b.updates, u)
+	s.notify()
+	s.mu.Unlock()
+}
+
+// bot must be called with mu held.
+func (s *Server) bot(token string) *bot {
+	b, ok := s.bots[token]
+	if !ok {
+		s.nextUser++
+		b = &bot{
+			id:       s.nextUser,
+			username: fmt.Sprintf("impulse_%d_bot", s.nextUser),
+			read:     make(map[int64]int),
+		}
+		s.bots[token] = b
+	}
+	return b
+}
+
+// notify must be called with mu held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/bot")
+	token, method, ok := strings.Cut(path, "/")
+	if !ok || token == "" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	params, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+
+	switch method {
+	case "getMe":
+		s.mu.Lock()
+		b := s.bot(token)
+		writeResult(w, map[string]interface{}{"id": b.id, "is_bot": true, "first_name": "imPulse", "username": b.username})
+		s.mu.Unlock()
+	case "deleteWebhook", "setWebhook", "setMyCommands", "deleteMessage", "unpinChatMessage":
+		writeResult(w, true)
+	case "getUpdates":
+		s.getUpdates(w, r, token, params)
+	case "sendMessage", "sendPhoto":
+		s.send(w, token, method, params)
+	case "editMessageText", "editMessageCaption":
+		s.edit(w, token, params)
+	case "pinChatMessage":
+		s.pin(w, token, params)
+	case "answerCallbackQuery":
+		s.mu.Lock()
+		b := s.bot(token)
+		b.answers = append(b.answers, CallbackAnswer{ID: params["callback_query_id"], Text: params["text"]})
+		s.mu.Unlock()
+		writeResult(w, true)
+	case "getChat":
+		s.getChat(w, params)
+	case "getChatMember":
+		writeResult(w, map[string]interface{}{"status": "creator", "user": user(parseInt(params["user_id"]))})
+	default:
+		writeError(w, http.StatusNotFound, "Not Found: method "+method)
+	}
+}
+
+func (s *Server) getUpdates(w http.ResponseWriter, r *http.Request, token string, params map[string]string) {
+	offset := int(parseInt(params["offset"]))
+	deadline := time.After(time.Duration(parseInt(params["timeout"])) * time.Second)
+
+	for {
+		s.mu.Lock()
+		b := s.bot(token)
+		kept := b.updates[:0]
+		for _, u := range b.updates {
+			if u["update_id"].(int) >= offset {
+				kept = append(kept, u)
+			}
+		}
+		b.updates = kept
+		updates := append([]map[string]interface{}{}, kept...)
+		changed := s.changed
+		s.mu.Unlock()
+
+		if len(updates) > 0 {
+			writeResult(w, updates)
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-deadline:
+			writeResult(w, updates)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) send(w http.ResponseWriter, token string, method string, params map[string]string) {
+	chatID, err := chatID(params["chat_id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat not found")
+		return
+	}
+
+	message := &Message{
+		ChatID:   chatID,
+		ThreadID: int(parseInt(params["message_thread_id"])),
+		Text:     params["text"],
+		Silent:   params["disable_notification"] == "true",
+	}
+	if method == "sendPhoto" {
+		message.Photo = true
+		message.Text = params["caption"]
+	}
+
+	s.mu.Lock()
+	b := s.bot(token)
+	b.nextID++
+	message.ID = b.nextID
+	b.messages = append(b.messages, message)
+	result := messageJSON(b, message)
+	s.notify()
+	s.mu.Unlock()
+
+	writeResult(w, result)
+}
+
+func (s *Server) edit(w http.ResponseWriter, token string, params map[string]string) {
+	chatID, _ := chatID(params["chat_id"])
+	messageID := int(parseInt(params["message_id"]))
+	text := params["text"]
+	if text == "" {
+		text = params["caption"]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bot(token)
+	for _, message := range b.messages {
+		if message.ChatID != chatID || message.ID != messageID {
+			continue
+		}
+		if message.Text == text {
+			writeError(w, http.StatusBadRequest, "Bad Request: message is not modified")
+			return
+		}
+
+		edited := *message
+		edited.Text = text
+		edited.Edited = true
+		message.Text = text
+		b.messages = append(b.messages, &edited)
+		s.notify()
+		writeResult(w, messageJSON(b, &edited))
+		return
+	}
+	writeError(w, http.StatusBadRequest, "Bad Request: message to edit not found")
+}
+
+func (s *Server) pin(w http.ResponseWriter, token string, params map[string]string) {
+	chatID, _ := chatID(params["chat_id"])
+	messageID := int(parseInt(params["message_id"]))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, message := range s.bot(token).messages {
+		if message.ChatID == chatID && message.ID == messageID {
+			message.Pinned = true
+		}
+	}
+	writeResult(w, true)
+}
+
+func (s *Server) getChat(w http.ResponseWriter, params map[string]string) {
+	s.mu.Lock()
+	id, ok := s.chats[strings.TrimPrefix(params["chat_id"], "@")]
+	s.mu.Unlock()
+
+	var err error
+	if !ok {
+		id, err = chatID(params["chat_id"])
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat not found")
+		return
+	}
+
+	chatType := "private"
+	if id < 0 {
+		chatType = "supergroup"
+	}
+	writeResult(w, map[string]interface{}{"id": id, "type": chatType, "title": params["chat_id"]})
+}
+
+func readParams(r *http.Request) (map[string]string, error) {
+	params := make(map[string]string)
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		for key, values := range r.MultipartForm.Value {
+			params[key] = values[0]
+		}
+		return params, nil
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		return nil, err
+	}
+	for key, value := range body {
+		switch v := value.(type) {
+		case string:
+			params[key] = v
+		case nil:
+		default:
+			data, _ := json.Marshal(v)
+			params[key] = string(data)
+		}
+	}
+	return params, nil
+}
+
+func messageJSON(b *bot, message *Message) map[string]interface{} {
+	result := map[string]interface{}{
+		"message_id": message.ID,
+		"date":       time.Now().Unix(),
+		"from":       map[string]interface{}{"id": b.id, "is_bot": true, "first_name": "imPulse", "username": b.username},
+		"chat":       map[string]interface{}{"id": message.ChatID, "type": "private"},
+	}
+	if message.ThreadID != 0 {
+		result["message_thread_id"] = message.ThreadID
+	}
+	if message.Photo {
+		result["photo"] = []map[string]interface{}{{"file_id": fmt.Sprintf("photo-%d", message.ID), "width": 800, "height": 600}}
+		result["caption"] = message.Text
+	} else {
+		result["text"] = message.Text
+	}
+	if message.Edited {
+		result["edit_date"] = time.Now().Unix()
+	}
+	return result
+}
+
+func user(id int64) map[string]interface{} {
+	return map[string]interface{}{"id": id, "is_bot": false, "first_name": "User", "username": fmt.Sprintf("user%d", id), "language_code": "en"}
+}
+
+func chatID(value string) (int64, error) {
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func parseInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result}); err != nil {
+		log.Printf("Fake Telegram response failed: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error_code": status, "description": description})
+}
@@ -0,0 +1,143 @@
+package futures
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type Settings struct {
+	OpenInterestPercent float64
+	OpenInterestWindow  time.Duration
+	FundingPercent      float64
+}
+
+type Ticker struct {
+	Symbol        string
+	ChangePercent float64
+}
+
+type point struct {
+	at    time.Time
+	value float64
+}
+
+// Scanner remembers open interest over the window and on which side of the
+// threshold each funding rate was, so each spike and each crossing is
+// reported once.
+type Scanner struct {
+	openInterest map[string][]point
+	spikeFired   map[string]time.Time
+	fundingSide  map[string]int
+}
+
+func NewScanner() *Scanner {
+	return &Scanner{
+		openInterest: make(map[string][]point),
+		spikeFired:   make(map[string]time.Time),
+		fundingSide:  make(map[string]int),
+	}
+}
+
+// Seen is false until open interest was recorded for the symbol, which is
+// when its window should be seeded from history.
+func (s *Scanner) Seen(symbol string) bool {
+	_, ok := s.openInterest[symbol]
+	return ok
+}
+
+func (s *Scanner) RecordOpenInterest(symbol string, value float64, at time.Time, window time.Duration) {
+	points := append(s.openInterest[symbol], point{at: at, value: value})
+	cutoff := at.Add(-window)
+	for len(points) > 0 && points[0].at.Before(cutoff) {
+		points = points[1:]
+	}
+	s.openInterest[symbol] = points
+}
+
+// Spike is an open interest rise within the window, from the lowest value
+// to the latest one.
+type Spike struct {
+	From   float64
+	To     float64
+	Change float64
+}
+
+// OpenInterestSpike reports the rise of the latest open interest over the
+// lowest value within the window once it reaches the threshold. After an
+// alert the symbol stays quiet for one window.
+func (s *Scanner) OpenInterestSpike(symbol string, settings Settings, now time.Time) (Spike, bool) {
+	points := s.openInterest[symbol]
+	if len(points) < 2 || settings.OpenInterestPercent <= 0 {
+		return Spike{}, false
+	}
+	if fired, ok := s.spikeFired[symbol]; ok && now.Sub(fired) < settings.OpenInterestWindow {
+		return Spike{}, false
+	}
+
+	low := points[0].value
+	for _, p := range points[:len(points)-1] {
+		low = math.Min(low, p.value)
+	}
+	if low <= 0 {
+		return Spike{}, false
+	}
+
+	latest := points[len(points)-1].value
+	change := (latest/low - 1) * 100
+	if change < settings.OpenInterestPercent {
+		return Spike{}, false
+	}
+	s.spikeFired[symbol] = now
+	return Spike{From: low, To: latest, Change: change}, true
+}
+
+// ExtremeFunding is true when the funding rate crosses the threshold in
+// either direction. It stays false while the rate remains beyond it and
+// re-arms once the rate is back within. The first rate of a symbol only sets
+// its side, so a restart doesn't report every rate that is already extreme.
+// The rate is a fraction, as Binance reports it.
+func (s *Scanner) ExtremeFunding(symbol string, rate float64, settings Settings) bool {
+	side := 0
+	if settings.FundingPercent > 0 && math.Abs(rate*100) >= settings.FundingPercent {
+		side = 1
+		if rate < 0 {
+			side = -1
+		}
+	}
+
+	previous, seen := s.fundingSide[symbol]
+	s.fundingSide[symbol] = side
+	return seen && side != 0 && side != previous
+}
+
+// Candidates picks the symbols worth polling open interest for: the extra
+// ones first, then the biggest 24h movers, up to limit in total.
+func Candidates(tickers []Ticker, extra []string, limit int) []string {
+	listed := make(map[string]bool, len(tickers))
+	for _, ticker := range tickers {
+		listed[ticker.Symbol] = true
+	}
+
+	seen := make(map[string]bool)
+	var symbols []string
+	add := func(symbol string) {
+		if len(symbols) < limit && listed[symbol] && !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	for _, symbol := range extra {
+		add(symbol)
+	}
+
+	movers := append([]Ticker(nil), tickers...)
+	sort.Slice(movers, func(i, j int) bool {
+		return math.Abs(movers[i].ChangePercent) > math.Abs(movers[j].ChangePercent)
+	})
+	for _, ticker := range movers {
+		add(ticker.Symbol)
+	}
+	return symbols
+}
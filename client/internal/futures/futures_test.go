@@ -0,0 +1,139 @@
+package futures
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)
+
+var settings = Settings{OpenInterestPercent: 10, OpenInterestWindow: 15 * time.Minute, FundingPercent: 0.1}
+
+func TestOpenInterestSpikeFromLowest(t *testing.T) {
+	s := NewScanner()
+	for i, value := range []float64{100, 95, 98, 104} {
+		s.RecordOpenInterest("PEPEUSDT", value, start.Add(time.Duration(i)*5*time.Minute), settings.OpenInterestWindow)
+	}
+
+	// 100 fell out of the window, the rise counts from 95.
+	spike, ok := s.OpenInterestSpike("PEPEUSDT", settings, start.Add(15*time.Minute))
+	if ok {
+		t.Fatalf("got a spike of %+v below the threshold", spike)
+	}
+
+	s.RecordOpenInterest("PEPEUSDT", 105, start.Add(16*time.Minute), settings.OpenInterestWindow)
+	spike, ok = s.OpenInterestSpike("PEPEUSDT", settings, start.Add(16*time.Minute))
+	if !ok {
+		t.Fatal("no spike at 95 → 105")
+	}
+	if spike.From != 95 || spike.To != 105 || spike.Change < 10.52 || spike.Change > 10.53 {
+		t.Errorf("spike = %+v, want 95 → 105", spike)
+	}
+}
+
+func TestOpenInterestSpikeOncePerWindow(t *testing.T) {
+	s := NewScanner()
+	record := func(value float64, at time.Duration) {
+		s.RecordOpenInterest("PEPEUSDT", value, start.Add(at), settings.OpenInterestWindow)
+	}
+
+	record(100, 0)
+	record(120, time.Minute)
+	if _, ok := s.OpenInterestSpike("PEPEUSDT", settings, start.Add(time.Minute)); !ok {
+		t.Fatal("no spike at 100 → 120")
+	}
+
+	record(130, 2*time.Minute)
+	if _, ok := s.OpenInterestSpike("PEPEUSDT", settings, start.Add(2*time.Minute)); ok {
+		t.Error("spike reported twice within the window")
+	}
+	if _, ok := s.OpenInterestSpike("DOGEUSDT", settings, start.Add(2*time.Minute)); ok {
+		t.Error("spike for a symbol without open interest")
+	}
+
+	record(150, 16*time.Minute)
+	spike, ok := s.OpenInterestSpike("PEPEUSDT", settings, start.Add(16*time.Minute))
+	if !ok || spike.From != 120 {
+		t.Errorf("after the window got %+v, %v, want a spike from 120", spike, ok)
+	}
+}
+
+func TestOpenInterestSpikeNeedsHistory(t *testing.T) {
+	s := NewScanner()
+	if s.Seen("PEPEUSDT") {
+		t.Error("Seen before anything was recorded")
+	}
+	s.RecordOpenInterest("PEPEUSDT", 100, start, settings.OpenInterestWindow)
+	if !s.Seen("PEPEUSDT") {
+		t.Error("not Seen after recording")
+	}
+	if _, ok := s.OpenInterestSpike("PEPEUSDT", settings, start); ok {
+		t.Error("spike from a single value")
+	}
+
+	s.RecordOpenInterest("PEPEUSDT", 200, start.Add(time.Minute), settings.OpenInterestWindow)
+	if _, ok := s.OpenInterestSpike("PEPEUSDT", Settings{OpenInterestWindow: time.Hour}, start.Add(time.Minute)); ok {
+		t.Error("spike with the threshold off")
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	tickers := []Ticker{
+		{Symbol: "BTCUSDT", ChangePercent: 1},
+		{Symbol: "PEPEUSDT", ChangePercent: 25},
+		{Symbol: "WIFUSDT", ChangePercent: -30},
+		{Symbol: "DOGEUSDT", ChangePercent: 5},
+	}
+
+	tests := []struct {
+		name  string
+		extra []string
+		limit int
+		want  []string
+	}{
+		{"movers by size of the move", nil, 3, []string{"WIFUSDT", "PEPEUSDT", "DOGEUSDT"}},
+		{"tracked first", []string{"BTCUSDT"}, 2, []string{"BTCUSDT", "WIFUSDT"}},
+		{"no duplicates", []string{"PEPEUSDT", "PEPEUSDT"}, 3, []string{"PEPEUSDT", "WIFUSDT", "DOGEUSDT"}},
+		{"only listed contracts", []string{"BONKUSDT"}, 1, []string{"WIFUSDT"}},
+		{"fewer than the limit", nil, 10, []string{"WIFUSDT", "PEPEUSDT", "DOGEUSDT", "BTCUSDT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Candidates(tickers, tt.extra, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtremeFunding(t *testing.T) {
+	s := NewScanner()
+	steps := []struct {
+		symbol string
+		rate   float64
+		want   bool
+	}{
+		{"PEPEUSDT", 0.002, false}, // already extreme at the first scan
+		{"PEPEUSDT", 0.003, false},
+		{"PEPEUSDT", 0.0005, false},
+		{"PEPEUSDT", 0.001, true}, // 0.1% is on the threshold
+		{"PEPEUSDT", 0.002, false},
+		{"PEPEUSDT", -0.0015, true}, // flipped sides without re-arming
+		{"PEPEUSDT", -0.0002, false},
+		{"PEPEUSDT", -0.0011, true},
+		{"WIFUSDT", 0.0001, false},
+		{"WIFUSDT", 0.0012, true},
+	}
+	for i, step := range steps {
+		if got := s.ExtremeFunding(step.symbol, step.rate, settings); got != step.want {
+			t.Errorf("step %d: ExtremeFunding(%s, %v) = %v, want %v", i, step.symbol, step.rate, got, step.want)
+		}
+	}
+
+	off := Settings{}
+	s.ExtremeFunding("DOGEUSDT", 0, off)
+	if s.ExtremeFunding("DOGEUSDT", 0.05, off) {
+		t.Error("funding alert with the threshold off")
+	}
+}
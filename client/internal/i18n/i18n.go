@@ -0,0 +1,471 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	English Language = "en"
+	Russian Language = "ru"
+)
+
+type Key string
+
+const (
+	EnterEmail             Key = "enter_email"
+	InvalidEmail           Key = "invalid_email"
+	VerificationCodeSent   Key = "verification_code_sent"
+	VerificationFailed     Key = "verification_failed"
+	TrackingLaunched       Key = "tracking_launched"
+	TrackingLaunchedSingle Key = "tracking_launched_single"
+	SecondBotLaunched      Key = "second_bot_launched"
+	EnterChangePercent     Key = "enter_change_percent"
+	ChangePercentChanged   Key = "change_percent_changed"
+	EnterPumpPercent       Key = "enter_pump_percent"
+	PumpPercentChanged     Key = "pump_percent_changed"
+	EnterWaitTime          Key = "enter_wait_time"
+	WaitTimeChanged        Key = "wait_time_changed"
+	InvalidPercent         Key = "invalid_percent"
+	InvalidWaitTime        Key = "invalid_wait_time"
+	SignalChatRejected     Key = "signal_chat_rejected"
+	SignalChatConfirmed    Key = "signal_chat_confirmed"
+	ListChatRejected       Key = "list_chat_rejected"
+	ListChatConfirmed      Key = "list_chat_confirmed"
+	DestinationChanged     Key = "destination_changed"
+	NotChatAdmin           Key = "not_chat_admin"
+	BotCannotPost          Key = "bot_cannot_post"
+	TemplateUsage          Key = "template_usage"
+	TemplateChanged        Key = "template_changed"
+	LiveBoardEnabled       Key = "live_board_enabled"
+	LiveBoardDisabled      Key = "live_board_disabled"
+	LiveBoardEmpty         Key = "live_board_empty"
+	LanguageUsage          Key = "language_usage"
+	LanguageChanged        Key = "language_changed"
+	ServiceRestarted       Key = "service_restarted"
+	ServiceRestartedSingle Key = "service_restarted_single"
+	QuietUsage             Key = "quiet_usage"
+	QuietNotSet            Key = "quiet_not_set"
+	QuietChanged           Key = "quiet_changed"
+	QuietDisabled          Key = "quiet_disabled"
+	QuietInvalid           Key = "quiet_invalid"
+	UrgentUsage            Key = "urgent_usage"
+	UrgentChanged          Key = "urgent_changed"
+	QuietDigest            Key = "quiet_digest"
+	QuietMoreWhales        Key = "quiet_more_whales"
+	QuietMoreCoins         Key = "quiet_more_coins"
+	QuietMorePumps         Key = "quiet_more_pumps"
+	QuietMoreRules         Key = "quiet_more_rules"
+	QuietMoreIndicators    Key = "quiet_more_indicators"
+	QuietMoreFutures       Key = "quiet_more_futures"
+	RearmUsage             Key = "rearm_usage"
+	RearmChanged           Key = "rearm_changed"
+	RearmDisabled          Key = "rearm_disabled"
+	RearmNone              Key = "rearm_none"
+	BaselineUsage          Key = "baseline_usage"
+	BaselineChanged        Key = "baseline_changed"
+	IndicatorUsage         Key = "indicator_usage"
+	IndicatorAdded         Key = "indicator_added"
+	IndicatorRemoved       Key = "indicator_removed"
+	IndicatorNotFound      Key = "indicator_not_found"
+	IndicatorList          Key = "indicator_list"
+	IndicatorNone          Key = "indicator_none"
+	IndicatorAllSymbols    Key = "indicator_all_symbols"
+	IndicatorUnknownSymbol Key = "indicator_unknown_symbol"
+	IndicatorCheckFailed   Key = "indicator_check_failed"
+	RuleUsage              Key = "rule_usage"
+	RuleAdded              Key = "rule_added"
+	RuleInvalid            Key = "rule_invalid"
+	RuleRemoved            Key = "rule_removed"
+	RuleNotFound           Key = "rule_not_found"
+	RuleList               Key = "rule_list"
+	RuleNone               Key = "rule_none"
+	ExchangesUsage         Key = "exchanges_usage"
+	ExchangesChanged       Key = "exchanges_changed"
+	ExchangesInvalid       Key = "exchanges_invalid"
+	FuturesUsage           Key = "futures_usage"
+	FuturesEnabled         Key = "futures_enabled"
+	FuturesDisabled        Key = "futures_disabled"
+	FuturesChanged         Key = "futures_changed"
+	FuturesStateOn         Key = "futures_state_on"
+	FuturesStateOff        Key = "futures_state_off"
+	LiquidityUsage         Key = "liquidity_usage"
+	LiquidityChanged       Key = "liquidity_changed"
+	LiquidityOff           Key = "liquidity_off"
+	WhalesUsage            Key = "whales_usage"
+	WhalesEnabled          Key = "whales_enabled"
+	WhalesDisabled         Key = "whales_disabled"
+	WhalesChanged          Key = "whales_changed"
+	WhalesOff              Key = "whales_off"
+	WhalesStateOn          Key = "whales_state_on"
+	WhalesStateOff         Key = "whales_state_off"
+	WhaleBuy               Key = "whale_buy"
+	WhaleSell              Key = "whale_sell"
+	WhaleTrades            Key = "whale_trades"
+	ListingsUsage          Key = "listings_usage"
+	ListingsEnabled        Key = "listings_enabled"
+	ListingsDisabled       Key = "listings_disabled"
+	ListingsStateOn        Key = "listings_state_on"
+	ListingsStateOff       Key = "listings_state_off"
+	ListingNew             Key = "listing_new"
+	ListingTrading         Key = "listing_trading"
+	ListingDelisting       Key = "listing_delisting"
+	LabelPrice             Key = "label_price"
+	LabelChange24h         Key = "label_change_24h"
+	LabelPriceShort        Key = "label_price_short"
+	LabelChange24hShort    Key = "label_change_24h_short"
+	LabelSinceAlert        Key = "label_since_alert"
+	LabelPump              Key = "label_pump"
+	LabelFrom              Key = "label_from"
+	LabelSignal            Key = "label_signal"
+	LabelRule              Key = "label_rule"
+	LabelOpenInterest      Key = "label_open_interest"
+	LabelFunding           Key = "label_funding"
+	LabelNextFunding       Key = "label_next_funding"
+	LabelSettledFunding    Key = "label_settled_funding"
+	LabelContracts         Key = "label_contracts"
+	LabelBook              Key = "label_book"
+	LabelBids              Key = "label_bids"
+	LabelAsks              Key = "label_asks"
+	LabelImbalance         Key = "label_imbalance"
+	LabelSide              Key = "label_side"
+	LabelSize              Key = "label_size"
+	LabelStatus            Key = "label_status"
+)
+
+var catalog = map[Language]map[Key]string{
+	English: {
+		EnterEmail:             "Please enter your email address for verification",
+		InvalidEmail:           "Invalid email value, please enter a valid email",
+		VerificationCodeSent:   "A verification code has been sent to your email. Please enter it.",
+		VerificationFailed:     "Verification failed. Please enter the correct verification code.",
+		TrackingLaunched:       "Tracking service launched.\nTo launch the second chatbot, which will receive notifications about the pump of crypto assets, you need to go to it:\n@%s\nand send the /start command.",
+		TrackingLaunchedSingle: "Tracking service launched.\nNotifications about the pump of crypto assets will be sent to this chat as well.\nTo receive them in a group or a forum topic, send /signalchat (pump signals) or /listchat (coins list) there, or use /signalchat @channel for a channel where the bot is an administrator.",
+		SecondBotLaunched:      "The service for monitoring coins that are being pumped has been launched",
+		EnterChangePercent:     "Please enter the new percent value (current value is %s)",
+		ChangePercentChanged:   "The percentage of pumping for tracked coins has been changed",
+		EnterPumpPercent:       "Please enter the new percent value (current percent is %s)",
+		PumpPercentChanged:     "The percentage expected for the pump has been changed",
+		EnterWaitTime:          "Please enter the new wait time in minutes (current wait time is %s min)",
+		WaitTimeChanged:        "The wait time for coin pumping has been changed",
+		InvalidPercent:         "Invalid percent value, please enter a valid number",
+		InvalidWaitTime:        "Invalid wait time value, please enter a valid number",
+		SignalChatRejected:     "This chat can't receive pump signals: %s",
+		SignalChatConfirmed:    "Pump signals will be sent here",
+		ListChatRejected:       "This chat can't receive the list of coins: %s",
+		ListChatConfirmed:      "The list of coins with 24h change will be sent here",
+		DestinationChanged:     "Destination changed to %s",
+		NotChatAdmin:           "only chat administrators can use this chat as an alert destination",
+		BotCannotPost:          "the bot is not allowed to post messages in this chat",
+		TemplateUsage:          "Usage: /template compact or /template verbose (current template is %s)",
+		TemplateChanged:        "Alerts will use the %s template",
+		LiveBoardEnabled:       "Live board enabled, the list of coins will be kept in a single pinned message and updated every minute",
+		LiveBoardDisabled:      "Live board disabled, the list of coins will be sent as a new message every minute",
+		LiveBoardEmpty:         "🔹 No coins above the 24h change threshold right now",
+		LanguageUsage:          "Usage: /language en or /language ru (current language is %s)",
+		LanguageChanged:        "Language changed to English",
+		ServiceRestarted:       "⛔️The service has been restarted.\nYou need to resend the /start command in each chatbot.",
+		ServiceRestartedSingle: "⛔️The service has been restarted.\nYou need to resend the /start command.",
+		QuietUsage:             "Usage: /quiet 23:00-07:00 Europe/Moscow or /quiet off (current quiet hours: %s)",
+		QuietNotSet:            "not set",
+		QuietChanged:           "Quiet hours set to %s. Messages will be collected into a digest, pump alerts above %s will arrive silently",
+		QuietDisabled:          "Quiet hours disabled",
+		QuietInvalid:           "Invalid quiet hours, use the format 23:00-07:00 Europe/Moscow",
+		UrgentUsage:            "Usage: /urgent 15 (pump alerts above this percent are delivered silently during quiet hours, current value is %s)",
+		UrgentChanged:          "Pump alerts above %s will be delivered silently during quiet hours",
+		QuietDigest:            "🌙 Messages held during quiet hours:\n\n",
+		QuietMoreWhales:        "🐋 … and %d more whale alerts\n",
+		QuietMoreCoins:         "✅ … and %d more new coins\n",
+		QuietMorePumps:         "🚀 … and %d more pump alerts\n",
+		QuietMoreRules:         "⚡️ … and %d more rule alerts\n",
+		QuietMoreIndicators:    "📐 … and %d more indicator alerts\n",
+		QuietMoreFutures:       "🧲 … and %d more futures alerts\n",
+		RearmUsage:             "Usage:\n/rearm cooldown 30 - minutes between alerts for the same coin\n/rearm step 5 - alert again on every additional 5%%\n/rearm step 5 10 20 - alert again at these pump levels\n/rearm retrace 3 - re-arm the coin after a 3%% pullback from its peak\n/rearm off - alert only once per coin\n\nCurrent settings: cooldown %s min, steps %s, retrace %s",
+		RearmChanged:           "Re-arm settings changed: cooldown %s min, steps %s, retrace %s",
+		RearmDisabled:          "Re-arm disabled, each coin is reported once until it drops below the 24h threshold",
+		RearmNone:              "off",
+		BaselineUsage:          "Usage:\n/baseline first - price when the coin crossed the 24h threshold, within the wait time\n/baseline min 15 - lowest price over the last 15 minutes\n/baseline vwap 30 - volume weighted average price over the last 30 minutes\n/baseline lookback 10 - price 10 minutes ago\n\nCurrent baseline: %s",
+		BaselineChanged:        "Pumps will be measured from the %s baseline",
+		IndicatorUsage:         "Usage:\n/indicator add BTCUSDT 15m rsi 14 above 70\n/indicator add all 5m ema 9 21 up\n/indicator add ETHUSDT 1h sma 50 200 down\n/indicator add all 15m macd up\n/indicator add SOLUSDT 1h bollinger 20 2 up\n/indicator list\n/indicator del 3\n\n\"all\" checks every coin currently tracked above the 24h threshold",
+		IndicatorAdded:         "Indicator alert #%d added: %s %s",
+		IndicatorRemoved:       "Indicator alert #%d removed",
+		IndicatorNotFound:      "Indicator alert #%d not found",
+		IndicatorList:          "Indicator alerts:\n",
+		IndicatorNone:          "No indicator alerts, add one with /indicator add",
+		IndicatorAllSymbols:    "all tracked coins",
+		IndicatorUnknownSymbol: "%s is not a trading pair, check the name, e.g. BTCUSDT or BYBIT:PEPEUSDT",
+		IndicatorCheckFailed:   "Could not check %s against the exchange, please try again later",
+		RuleUsage:              "Usage:\n/rule add ch24 > 15 AND ch5m > 3 AND qvol > 1M\n/rule list\n/rule del 2\n\nVariables: %s\nOperators: > >= < <= = !=, AND, OR, NOT and parentheses. Numbers accept K, M and B suffixes.",
+		RuleAdded:              "Rule #%d added, coins will be reported once each time they start matching it",
+		RuleInvalid:            "Invalid rule: %s",
+		RuleRemoved:            "Rule #%d removed",
+		RuleNotFound:           "Rule #%d not found",
+		RuleList:               "Rules:\n",
+		RuleNone:               "No rules, add one with /rule add",
+		ExchangesUsage:         "Monitored exchanges: %s\nTo change them send /exchanges followed by any of: %s\nCoins from venues other than Binance are shown with the venue prefix, like BYBIT:PEPEUSDT.",
+		ExchangesChanged:       "Monitored exchanges changed to %s",
+		ExchangesInvalid:       "Unknown exchange %s, supported: %s",
+		FuturesUsage:           "Usage:\n/futures on - scan USDⓈ-M perpetual futures\n/futures off - stop scanning futures\n/futures oi 10 15 - alert when open interest grows 10%% within 15 minutes\n/futures funding 0.1 - alert when the funding rate crosses ±0.1%%\n\nFutures scanning is %s: open interest %s within %s min, funding ±%s",
+		FuturesEnabled:         "Futures scanning enabled: open interest %s within %s min, funding ±%s",
+		FuturesDisabled:        "Futures scanning disabled",
+		FuturesChanged:         "Futures alerts changed: open interest %s within %s min, funding ±%s",
+		FuturesStateOn:         "on",
+		FuturesStateOff:        "off",
+		LiquidityUsage:         "Usage:\n/liquidity band 2 - show the order book within ±2%% of the price on pump alerts\n/liquidity min 50000 - drop pump alerts when less than 50 000 USDT rests within the band\n/liquidity off - never drop pump alerts\n\nCurrent settings: band ±%s, minimum depth %s",
+		LiquidityChanged:       "Liquidity settings changed: band ±%s, minimum depth %s",
+		LiquidityOff:           "off",
+		WhalesUsage:            "Usage:\n/whales on - report large trades on the coins being tracked\n/whales off - stop reporting large trades\n/whales trade 100000 - report single trades of 100 000 USDT or more\n/whales burst 250000 10 - report trades on one side adding up to 250 000 USDT within 10 seconds\n/whales trade 0 or /whales burst 0 - turn that kind off\n\nWhale alerts are %s: single trades %s, bursts %s",
+		WhalesEnabled:          "Whale alerts enabled: single trades %s, bursts %s",
+		WhalesDisabled:         "Whale alerts disabled",
+		WhalesChanged:          "Whale alerts changed: single trades %s, bursts %s",
+		WhalesOff:              "off",
+		WhalesStateOn:          "on",
+		WhalesStateOff:         "off",
+		WhaleBuy:               "buy",
+		WhaleSell:              "sell",
+		WhaleTrades:            "(%d trades)",
+		ListingsUsage:          "Usage:\n/listings on - notify about new Binance USDT pairs, trading openings and delistings\n/listings off - stop listing notifications\n\nListing notifications are %s",
+		ListingsEnabled:        "Listing notifications enabled",
+		ListingsDisabled:       "Listing notifications disabled",
+		ListingsStateOn:        "on",
+		ListingsStateOff:       "off",
+		ListingNew:             "New listing",
+		ListingTrading:         "Trading opened",
+		ListingDelisting:       "Trading stopped",
+		LabelPrice:             "Price",
+		LabelChange24h:         "24h change",
+		LabelPriceShort:        "P",
+		LabelChange24hShort:    "Ch24h",
+		LabelSinceAlert:        "Since alert",
+		LabelPump:              "Pump",
+		LabelFrom:              "from",
+		LabelSignal:            "Signal",
+		LabelRule:              "Rule",
+		LabelOpenInterest:      "Open interest",
+		LabelFunding:           "Funding",
+		LabelNextFunding:       "next in",
+		LabelSettledFunding:    "Last settled",
+		LabelContracts:         "Contracts",
+		LabelBook:              "Book",
+		LabelBids:              "bids",
+		LabelAsks:              "asks",
+		LabelImbalance:         "imbalance",
+		LabelSide:              "Side",
+		LabelSize:              "Size",
+		LabelStatus:            "Status",
+	},
+	Russian: {
+		EnterEmail:             "Пожалуйста, введите ваш адрес электронной почты для подтверждения",
+		InvalidEmail:           "Неверный адрес электронной почты, пожалуйста, введите корректный адрес",
+		VerificationCodeSent:   "Код подтверждения отправлен на вашу почту. Пожалуйста, введите его.",
+		VerificationFailed:     "Подтверждение не удалось. Пожалуйста, введите правильный код подтверждения.",
+		TrackingLaunched:       "Сервис отслеживания запущен.\nЧтобы запустить второй чат-бот, который будет присылать уведомления о пампах криптоактивов, перейдите в него:\n@%s\nи отправьте команду /start.",
+		TrackingLaunchedSingle: "Сервис отслеживания запущен.\nУведомления о пампах криптоактивов также будут приходить в этот чат.\nЧтобы получать их в группе или теме форума, отправьте там /signalchat (сигналы о пампах) или /listchat (список монет), либо используйте /signalchat @channel для канала, где бот является администратором.",
+		SecondBotLaunched:      "Сервис отслеживания монет, которые пампят, запущен",
+		EnterChangePercent:     "Пожалуйста, введите новое значение процента (текущее значение %s)",
+		ChangePercentChanged:   "Процент роста для отслеживаемых монет изменён",
+		EnterPumpPercent:       "Пожалуйста, введите новое значение процента (текущий процент %s)",
+		PumpPercentChanged:     "Ожидаемый процент пампа изменён",
+		EnterWaitTime:          "Пожалуйста, введите новое время ожидания в минутах (текущее время ожидания %s мин)",
+		WaitTimeChanged:        "Время ожидания пампа монеты изменено",
+		InvalidPercent:         "Неверное значение процента, пожалуйста, введите число",
+		InvalidWaitTime:        "Неверное значение времени ожидания, пожалуйста, введите число",
+		SignalChatRejected:     "Этот чат не может получать сигналы о пампах: %s",
+		SignalChatConfirmed:    "Сигналы о пампах будут приходить сюда",
+		ListChatRejected:       "Этот чат не может получать список монет: %s",
+		ListChatConfirmed:      "Список монет с изменением за 24ч будет приходить сюда",
+		DestinationChanged:     "Получатель изменён на %s",
+		NotChatAdmin:           "только администраторы чата могут назначить его получателем уведомлений",
+		BotCannotPost:          "боту не разрешено публиковать сообщения в этом чате",
+		TemplateUsage:          "Использование: /template compact или /template verbose (текущий шаблон %s)",
+		TemplateChanged:        "Уведомления будут использовать шаблон %s",
+		LiveBoardEnabled:       "Живая доска включена, список монет будет храниться в одном закреплённом сообщении и обновляться каждую минуту",
+		LiveBoardDisabled:      "Живая доска выключена, список монет будет приходить новым сообщением каждую минуту",
+		LiveBoardEmpty:         "🔹 Сейчас нет монет выше порога изменения за 24ч",
+		LanguageUsage:          "Использование: /language en или /language ru (текущий язык %s)",
+		LanguageChanged:        "Язык изменён на русский",
+		ServiceRestarted:       "⛔️Сервис был перезапущен.\nНеобходимо повторно отправить команду /start в каждом чат-боте.",
+		ServiceRestartedSingle: "⛔️Сервис был перезапущен.\nНеобходимо повторно отправить команду /start.",
+		QuietUsage:             "Использование: /quiet 23:00-07:00 Europe/Moscow или /quiet off (текущие тихие часы: %s)",
+		QuietNotSet:            "не заданы",
+		QuietChanged:           "Тихие часы установлены на %s. Сообщения будут собраны в дайджест, сигналы о пампах выше %s придут без звука",
+		QuietDisabled:          "Тихие часы отключены",
+		QuietInvalid:           "Неверные тихие часы, используйте формат 23:00-07:00 Europe/Moscow",
+		UrgentUsage:            "Использование: /urgent 15 (сигналы о пампах выше этого процента приходят без звука в тихие часы, текущее значение %s)",
+		UrgentChanged:          "Сигналы о пампах выше %s будут приходить без звука в тихие часы",
+		QuietDigest:            "🌙 Сообщения, накопленные за тихие часы:\n\n",
+		QuietMoreWhales:        "🐋 … и ещё сигналов о китах: %d\n",
+		QuietMoreCoins:         "✅ … и ещё новых монет: %d\n",
+		QuietMorePumps:         "🚀 … и ещё сигналов о пампах: %d\n",
+		QuietMoreRules:         "⚡️ … и ещё срабатываний правил: %d\n",
+		QuietMoreIndicators:    "📐 … и ещё сигналов индикаторов: %d\n",
+		QuietMoreFutures:       "🧲 … и ещё сигналов по фьючерсам: %d\n",
+		RearmUsage:             "Использование:\n/rearm cooldown 30 - минуты между сигналами по одной монете\n/rearm step 5 - повторный сигнал на каждые дополнительные 5%%\n/rearm step 5 10 20 - повторные сигналы на этих уровнях пампа\n/rearm retrace 3 - сбросить сигнал после отката на 3%% от пика\n/rearm off - один сигнал на монету\n\nТекущие настройки: пауза %s мин, шаги %s, откат %s",
+		RearmChanged:           "Настройки повторных сигналов изменены: пауза %s мин, шаги %s, откат %s",
+		RearmDisabled:          "Повторные сигналы отключены, каждая монета сообщается один раз, пока не опустится ниже порога 24ч",
+		RearmNone:              "выкл",
+		BaselineUsage:          "Использование:\n/baseline first - цена при пересечении порога 24ч, в пределах времени ожидания\n/baseline min 15 - минимальная цена за последние 15 минут\n/baseline vwap 30 - средневзвешенная по объёму цена за последние 30 минут\n/baseline lookback 10 - цена 10 минут назад\n\nТекущая база: %s",
+		BaselineChanged:        "Пампы будут считаться от базы %s",
+		IndicatorUsage:         "Использование:\n/indicator add BTCUSDT 15m rsi 14 above 70\n/indicator add all 5m ema 9 21 up\n/indicator add ETHUSDT 1h sma 50 200 down\n/indicator add all 15m macd up\n/indicator add SOLUSDT 1h bollinger 20 2 up\n/indicator list\n/indicator del 3\n\n\"all\" проверяет все монеты, которые сейчас отслеживаются выше порога 24ч",
+		IndicatorAdded:         "Индикаторный сигнал #%d добавлен: %s %s",
+		IndicatorRemoved:       "Индикаторный сигнал #%d удалён",
+		IndicatorNotFound:      "Индикаторный сигнал #%d не найден",
+		IndicatorList:          "Индикаторные сигналы:\n",
+		IndicatorNone:          "Индикаторных сигналов нет, добавьте их через /indicator add",
+		IndicatorAllSymbols:    "все отслеживаемые монеты",
+		IndicatorUnknownSymbol: "%s не торгуется, проверьте название, например BTCUSDT или BYBIT:PEPEUSDT",
+		IndicatorCheckFailed:   "Не удалось проверить %s на бирже, попробуйте позже",
+		RuleUsage:              "Использование:\n/rule add ch24 > 15 AND ch5m > 3 AND qvol > 1M\n/rule list\n/rule del 2\n\nПеременные: %s\nОператоры: > >= < <= = !=, AND, OR, NOT и скобки. Числа поддерживают суффиксы K, M и B.",
+		RuleAdded:              "Правило #%d добавлено, монеты будут сообщаться каждый раз, когда начинают ему соответствовать",
+		RuleInvalid:            "Неверное правило: %s",
+		RuleRemoved:            "Правило #%d удалено",
+		RuleNotFound:           "Правило #%d не найдено",
+		RuleList:               "Правила:\n",
+		RuleNone:               "Правил нет, добавьте их через /rule add",
+		ExchangesUsage:         "Отслеживаемые биржи: %s\nЧтобы изменить их, отправьте /exchanges и любые из: %s\nМонеты с бирж, кроме Binance, показываются с префиксом биржи, например BYBIT:PEPEUSDT.",
+		ExchangesChanged:       "Отслеживаемые биржи изменены на %s",
+		ExchangesInvalid:       "Неизвестная биржа %s, поддерживаются: %s",
+		FuturesUsage:           "Использование:\n/futures on - сканировать бессрочные фьючерсы USDⓈ-M\n/futures off - остановить сканирование фьючерсов\n/futures oi 10 15 - сигнал, когда открытый интерес вырос на 10%% за 15 минут\n/futures funding 0.1 - сигнал, когда ставка финансирования пересекает ±0,1%%\n\nСканирование фьючерсов %s: открытый интерес %s за %s мин, финансирование ±%s",
+		FuturesEnabled:         "Сканирование фьючерсов включено: открытый интерес %s за %s мин, финансирование ±%s",
+		FuturesDisabled:        "Сканирование фьючерсов выключено",
+		FuturesChanged:         "Фьючерсные сигналы изменены: открытый интерес %s за %s мин, финансирование ±%s",
+		FuturesStateOn:         "включено",
+		FuturesStateOff:        "выключено",
+		LiquidityUsage:         "Использование:\n/liquidity band 2 - показывать стакан в пределах ±2%% от цены в сигналах пампа\n/liquidity min 50000 - пропускать сигналы пампа, если в пределах диапазона меньше 50 000 USDT\n/liquidity off - никогда не пропускать сигналы пампа\n\nТекущие настройки: диапазон ±%s, минимальная глубина %s",
+		LiquidityChanged:       "Настройки ликвидности изменены: диапазон ±%s, минимальная глубина %s",
+		LiquidityOff:           "выкл",
+		WhalesUsage:            "Использование:\n/whales on - сообщать о крупных сделках по отслеживаемым монетам\n/whales off - не сообщать о крупных сделках\n/whales trade 100000 - сообщать о сделках от 100 000 USDT\n/whales burst 250000 10 - сообщать о сделках в одну сторону на 250 000 USDT за 10 секунд\n/whales trade 0 или /whales burst 0 - отключить этот вид\n\nСигналы о китах %s: сделки %s, серии %s",
+		WhalesEnabled:          "Сигналы о китах включены: сделки %s, серии %s",
+		WhalesDisabled:         "Сигналы о китах выключены",
+		WhalesChanged:          "Сигналы о китах изменены: сделки %s, серии %s",
+		WhalesOff:              "выкл",
+		WhalesStateOn:          "включены",
+		WhalesStateOff:         "выключены",
+		WhaleBuy:               "покупка",
+		WhaleSell:              "продажа",
+		WhaleTrades:            "(сделок: %d)",
+		ListingsUsage:          "Использование:\n/listings on - уведомлять о новых парах USDT на Binance, открытии торгов и делистингах\n/listings off - отключить уведомления о листингах\n\nУведомления о листингах %s",
+		ListingsEnabled:        "Уведомления о листингах включены",
+		ListingsDisabled:       "Уведомления о листингах выключены",
+		ListingsStateOn:        "включены",
+		ListingsStateOff:       "выключены",
+		ListingNew:             "Новый листинг",
+		ListingTrading:         "Торги открыты",
+		ListingDelisting:       "Торги остановлены",
+		LabelPrice:             "Цена",
+		LabelChange24h:         "Изменение за 24ч",
+		LabelPriceShort:        "Ц",
+		LabelChange24hShort:    "24ч",
+		LabelSinceAlert:        "С момента сигнала",
+		LabelPump:              "Памп",
+		LabelFrom:              "от",
+		LabelSignal:            "Сигнал",
+		LabelRule:              "Правило",
+		LabelOpenInterest:      "Открытый интерес",
+		LabelFunding:           "Финансирование",
+		LabelNextFunding:       "следующее через",
+		LabelSettledFunding:    "Последняя выплата",
+		LabelContracts:         "Контракты",
+		LabelBook:              "Стакан",
+		LabelBids:              "покупка",
+		LabelAsks:              "продажа",
+		LabelImbalance:         "дисбаланс",
+		LabelSide:              "Сторона",
+		LabelSize:              "Объём",
+		LabelStatus:            "Статус",
+	},
+}
+
+func ParseLanguage(code string) (Language, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+	if _, ok := catalog[Language(code)]; ok {
+		return Language(code), true
+	}
+	return English, false
+}
+
+func FromLanguageCode(code string) Language {
+	lang, _ := ParseLanguage(code)
+	return lang
+}
+
+func T(lang Language, key Key, args ...interface{}) string {
+	format, ok := catalog[lang][key]
+	if !ok {
+		format = catalog[English][key]
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func FormatNumber(lang Language, value float64, decimals int) string {
+	return localize(lang, strconv.FormatFloat(value, 'f', decimals, 64))
+}
+
+func FormatPercent(lang Language, value float64, signed bool) string {
+	number := FormatNumber(lang, value, 2)
+	if signed && value >= 0 {
+		number = "+" + number
+	}
+	return number + "%"
+}
+
+func FormatPrice(lang Language, price float64) string {
+	number := strings.TrimRight(strings.TrimRight(strconv.FormatFloat(price, 'f', 8, 64), "0"), ".")
+	return localize(lang, number)
+}
+
+var (
+	commaGroups = regexp.MustCompile(`^[-+]?[1-9][0-9]{0,2}(,[0-9]{3})+(\.[0-9]+)?$`)
+	dotGroups   = regexp.MustCompile(`^[-+]?[1-9][0-9]{0,2}(\.[0-9]{3})+,[0-9]+$`)
+)
+
+// ParseNumber reads numbers written either way FormatNumber prints them,
+// "1,000.5" and "1 000,5", and "1.000,5" as well. Commas are thousands
+// separators only in well formed groups, "100,000" or "1,000,000", otherwise
+// a comma is the decimal point, so "2,5" is 2.5 and "1,00,0" is an error.
+func ParseNumber(text string) (float64, error) {
+	text = strings.NewReplacer(" ", "", "\u00a0", "").Replace(strings.TrimSpace(text))
+	switch {
+	case commaGroups.MatchString(text):
+		text = strings.ReplaceAll(text, ",", "")
+	case dotGroups.MatchString(text):
+		text = strings.ReplaceAll(text, ".", "")
+	}
+	return strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+}
+
+func localize(lang Language, number string) string {
+	decimalSeparator, groupSeparator := ".", ","
+	if lang == Russian {
+		decimalSeparator, groupSeparator = ",", " "
+	}
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(number, ".")
+	var builder strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			builder.WriteString(groupSeparator)
+		}
+		builder.WriteRune(digit)
+	}
+
+	if hasFraction {
+		return sign + builder.String() + decimalSeparator + fraction
+	}
+	return sign + builder.String()
+}
@@ -0,0 +1,37 @@
+package i18n
+
+import "testing"
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{"2.5", 2.5},
+		{"2,5", 2.5},
+		{"100000", 100000},
+		{"100,000", 100000},
+		{"2,500", 2500},
+		{"0,500", 0.5},
+		{"2,50", 2.5},
+		{"1,000.5", 1000.5},
+		{"1 000,5", 1000.5},
+		{"1.000,5", 1000.5},
+		{"1,000,000", 1000000},
+		{"250 000", 250000},
+		{"-0,75", -0.75},
+		{"-1,500", -1500},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.text)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseNumber(%q) = %v, %v, want %v", tt.text, got, err, tt.want)
+		}
+	}
+
+	for _, text := range []string{"", "abc", "1,000.5.2", "1.2.3", "1,00,0", "1,000,5"} {
+		if got, err := ParseNumber(text); err == nil {
+			t.Errorf("ParseNumber(%q) = %v, want an error", text, got)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package indicators
+
+import "math"
+
+func SMA(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 || len(values) < period {
+		return result
+	}
+
+	sum := 0.0
+	for i, value := range values {
+		sum += value
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			result[i] = sum / float64(period)
+		}
+	}
+	return result
+}
+
+func EMA(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 || len(values) < period {
+		return result
+	}
+
+	k := 2 / float64(period+1)
+	result[period-1] = SMA(values[:period], period)[period-1]
+	for i := period; i < len(values); i++ {
+		result[i] = values[i]*k + result[i-1]*(1-k)
+	}
+	return result
+}
+
+func RSI(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if period <= 0 || len(values) <= period {
+		return result
+	}
+
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	result[period] = rsi(gain, loss)
+
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		currentGain, currentLoss := 0.0, 0.0
+		if change > 0 {
+			currentGain = change
+		} else {
+			currentLoss = -change
+		}
+		gain = (gain*float64(period-1) + currentGain) / float64(period)
+		loss = (loss*float64(period-1) + currentLoss) / float64(period)
+		result[i] = rsi(gain, loss)
+	}
+	return result
+}
+
+func MACD(values []float64, fast, slow, signal int) ([]float64, []float64, []float64) {
+	fastEMA := EMA(values, fast)
+	slowEMA := EMA(values, slow)
+
+	macd := nanSeries(len(values))
+	start := -1
+	for i := range values {
+		if math.IsNaN(fastEMA[i]) || math.IsNaN(slowEMA[i]) {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		macd[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	signalLine := nanSeries(len(values))
+	histogram := nanSeries(len(values))
+	if start < 0 {
+		return macd, signalLine, histogram
+	}
+
+	copy(signalLine[start:], EMA(macd[start:], signal))
+	for i := range values {
+		if !math.IsNaN(signalLine[i]) {
+			histogram[i] = macd[i] - signalLine[i]
+		}
+	}
+	return macd, signalLine, histogram
+}
+
+func Bollinger(values []float64, period int, k float64) ([]float64, []float64, []float64) {
+	middle := SMA(values, period)
+	upper := nanSeries(len(values))
+	lower := nanSeries(len(values))
+
+	for i := range values {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		variance := 0.0
+		for _, value := range values[i-period+1 : i+1] {
+			variance += (value - middle[i]) * (value - middle[i])
+		}
+		deviation := math.Sqrt(variance / float64(period))
+		upper[i] = middle[i] + k*deviation
+		lower[i] = middle[i] - k*deviation
+	}
+	return middle, upper, lower
+}
+
+func rsi(gain, loss float64) float64 {
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+func nanSeries(length int) []float64 {
+	series := make([]float64, length)
+	for i := range series {
+		series[i] = math.NaN()
+	}
+	return series
+}
@@ -0,0 +1,91 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+// wilderCloses is the StockCharts RSI example. Its table rounds the averages
+// at every step, the values below are the unrounded ones TA-Lib gives.
+var wilderCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+func equalSeries(t *testing.T, name string, got, want []float64, tolerance float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s has %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		switch {
+		case math.IsNaN(want[i]) && !math.IsNaN(got[i]):
+			t.Errorf("%s[%d] = %v, want NaN", name, i, got[i])
+		case !math.IsNaN(want[i]) && !(math.Abs(got[i]-want[i]) <= tolerance):
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	nan := math.NaN()
+	equalSeries(t, "SMA(3)", SMA([]float64{1, 2, 3, 4, 5}, 3), []float64{nan, nan, 2, 3, 4}, 1e-12)
+	equalSeries(t, "SMA(6)", SMA([]float64{1, 2, 3}, 6), []float64{nan, nan, nan}, 0)
+	equalSeries(t, "SMA(0)", SMA([]float64{1, 2}, 0), []float64{nan, nan}, 0)
+}
+
+func TestEMA(t *testing.T) {
+	nan := math.NaN()
+	// Seeded with the SMA of the first 3, then k = 0.5.
+	equalSeries(t, "EMA(3)", EMA([]float64{2, 4, 6, 8, 12}, 3), []float64{nan, nan, 4, 6, 9}, 1e-12)
+	equalSeries(t, "EMA(4)", EMA([]float64{1, 2, 3}, 4), []float64{nan, nan, nan}, 0)
+}
+
+func TestRSI(t *testing.T) {
+	got := RSI(wilderCloses, 14)
+	want := []float64{70.46, 66.25, 66.48, 69.35, 66.29, 57.92}
+	for i := 0; i < 14; i++ {
+		if !math.IsNaN(got[i]) {
+			t.Errorf("RSI[%d] = %v before a full period", i, got[i])
+		}
+	}
+	equalSeries(t, "RSI(14)", got[14:], want, 0.01)
+
+	equalSeries(t, "RSI of a flat series", RSI([]float64{5, 5, 5}, 2)[2:], []float64{50}, 0)
+	equalSeries(t, "RSI of a rising series", RSI([]float64{1, 2, 3}, 2)[2:], []float64{100}, 0)
+}
+
+func TestMACD(t *testing.T) {
+	// On a straight line an SMA seeded EMA lags by (period-1)/2 steps, so
+	// MACD(12,26) is 12.5-5.5 = 7 times the slope and the signal line
+	// settles on it.
+	values := make([]float64, 60)
+	for i := range values {
+		values[i] = 100 + float64(i)
+	}
+	macd, signal, histogram := MACD(values, 12, 26, 9)
+
+	if !math.IsNaN(macd[24]) || math.IsNaN(macd[25]) {
+		t.Errorf("MACD starts at %v, %v, want the 26th value", macd[24], macd[25])
+	}
+	if !math.IsNaN(signal[32]) || math.IsNaN(signal[33]) {
+		t.Errorf("signal starts at %v, %v, want 9 values after MACD", signal[32], signal[33])
+	}
+	for i := 33; i < len(values); i++ {
+		if math.Abs(macd[i]-7) > 1e-9 || math.Abs(signal[i]-7) > 1e-9 || math.Abs(histogram[i]) > 1e-9 {
+			t.Fatalf("at %d MACD %v, signal %v, histogram %v, want 7, 7, 0", i, macd[i], signal[i], histogram[i])
+		}
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	// Population standard deviation of the classic example is 2.
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	middle, upper, lower := Bollinger(values, 8, 2)
+	if middle[7] != 5 || upper[7] != 9 || lower[7] != 1 {
+		t.Errorf("got %v / %v / %v, want 5 / 9 / 1", middle[7], upper[7], lower[7])
+	}
+	if !math.IsNaN(upper[6]) || !math.IsNaN(lower[6]) {
+		t.Error("got bands before a full period")
+	}
+}
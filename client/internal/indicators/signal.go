@@ -0,0 +1,243 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Kind int
+
+const (
+	KindRSI Kind = iota
+	KindEMACross
+	KindSMACross
+	KindMACD
+	KindBollinger
+)
+
+var ErrInvalidSignal = errors.New("unknown indicator condition")
+
+var intervals = map[string]bool{
+	"1m": true, "3m": true, "5m": true, "15m": true, "30m": true,
+	"1h": true, "2h": true, "4h": true, "6h": true, "12h": true, "1d": true,
+}
+
+type Signal struct {
+	Kind     Kind
+	Interval string
+	Period   int
+	Slow     int
+	Level    float64
+	Up       bool
+}
+
+// ParseSignal accepts the interval followed by one of:
+//
+//	rsi 14 above 70
+//	ema 9 21 up
+//	sma 50 200 down
+//	macd up
+//	bollinger 20 2 up
+func ParseSignal(fields []string) (Signal, error) {
+	if len(fields) < 2 || !intervals[strings.ToLower(fields[0])] {
+		return Signal{}, ErrInvalidSignal
+	}
+	signal := Signal{Interval: strings.ToLower(fields[0])}
+	args := fields[2:]
+
+	var err error
+	switch strings.ToLower(fields[1]) {
+	case "rsi":
+		if len(args) != 3 {
+			return Signal{}, ErrInvalidSignal
+		}
+		signal.Kind = KindRSI
+		if signal.Period, err = parsePeriod(args[0]); err != nil {
+			return Signal{}, err
+		}
+		if signal.Up, err = parseDirection(args[1], "above", "below"); err != nil {
+			return Signal{}, err
+		}
+		if signal.Level, err = strconv.ParseFloat(args[2], 64); err != nil || signal.Level <= 0 || signal.Level >= 100 {
+			return Signal{}, ErrInvalidSignal
+		}
+	case "ema", "sma":
+		if len(args) != 3 {
+			return Signal{}, ErrInvalidSignal
+		}
+		signal.Kind = KindEMACross
+		if strings.ToLower(fields[1]) == "sma" {
+			signal.Kind = KindSMACross
+		}
+		if signal.Period, err = parsePeriod(args[0]); err != nil {
+			return Signal{}, err
+		}
+		if signal.Slow, err = parsePeriod(args[1]); err != nil || signal.Slow <= signal.Period {
+			return Signal{}, ErrInvalidSignal
+		}
+		if signal.Up, err = parseDirection(args[2], "up", "down"); err != nil {
+			return Signal{}, err
+		}
+	case "macd":
+		if len(args) != 1 {
+			return Signal{}, ErrInvalidSignal
+		}
+		signal.Kind = KindMACD
+		signal.Period, signal.Slow = 12, 26
+		if signal.Up, err = parseDirection(args[0], "up", "down"); err != nil {
+			return Signal{}, err
+		}
+	case "bollinger", "bb":
+		if len(args) != 3 {
+			return Signal{}, ErrInvalidSignal
+		}
+		signal.Kind = KindBollinger
+		if signal.Period, err = parsePeriod(args[0]); err != nil {
+			return Signal{}, err
+		}
+		if signal.Level, err = strconv.ParseFloat(args[1], 64); err != nil || signal.Level <= 0 {
+			return Signal{}, ErrInvalidSignal
+		}
+		if signal.Up, err = parseDirection(args[2], "up", "down"); err != nil {
+			return Signal{}, err
+		}
+	default:
+		return Signal{}, ErrInvalidSignal
+	}
+
+	return signal, nil
+}
+
+// Condition writes the signal back in the form ParseSignal reads, it is what
+// gets stored for the subscription.
+func (s Signal) Condition() string {
+	up, down := "up", "down"
+	if s.Kind == KindRSI {
+		up, down = "above", "below"
+	}
+	direction := down
+	if s.Up {
+		direction = up
+	}
+
+	switch s.Kind {
+	case KindRSI:
+		return fmt.Sprintf("%s rsi %d %s %s", s.Interval, s.Period, direction, strconv.FormatFloat(s.Level, 'f', -1, 64))
+	case KindEMACross:
+		return fmt.Sprintf("%s ema %d %d %s", s.Interval, s.Period, s.Slow, direction)
+	case KindSMACross:
+		return fmt.Sprintf("%s sma %d %d %s", s.Interval, s.Period, s.Slow, direction)
+	case KindMACD:
+		return fmt.Sprintf("%s macd %s", s.Interval, direction)
+	default:
+		return fmt.Sprintf("%s bollinger %d %s %s", s.Interval, s.Period, strconv.FormatFloat(s.Level, 'f', -1, 64), direction)
+	}
+}
+
+const maxLookback = 999
+
+func (s Signal) Lookback() int {
+	var lookback int
+	switch s.Kind {
+	case KindRSI:
+		lookback = s.Period*10 + 1
+	case KindEMACross:
+		lookback = s.Slow*4 + 1
+	case KindMACD:
+		lookback = s.Slow*4 + 9
+	case KindSMACross:
+		lookback = s.Slow + 1
+	default:
+		lookback = s.Period + 1
+	}
+	if lookback > maxLookback {
+		return maxLookback
+	}
+	return lookback
+}
+
+// Triggered reports whether the condition became true on the last of the
+// closed candles, so every crossing fires once.
+func (s Signal) Triggered(closes []float64) bool {
+	n := len(closes)
+	if n < 2 {
+		return false
+	}
+
+	switch s.Kind {
+	case KindRSI:
+		values := RSI(closes, s.Period)
+		return crossed(values[n-2], values[n-1], s.Level, s.Level, s.Up)
+	case KindEMACross:
+		fast, slow := EMA(closes, s.Period), EMA(closes, s.Slow)
+		return crossed(fast[n-2], fast[n-1], slow[n-2], slow[n-1], s.Up)
+	case KindSMACross:
+		fast, slow := SMA(closes, s.Period), SMA(closes, s.Slow)
+		return crossed(fast[n-2], fast[n-1], slow[n-2], slow[n-1], s.Up)
+	case KindMACD:
+		macd, signal, _ := MACD(closes, s.Period, s.Slow, 9)
+		return crossed(macd[n-2], macd[n-1], signal[n-2], signal[n-1], s.Up)
+	case KindBollinger:
+		_, upper, lower := Bollinger(closes, s.Period, s.Level)
+		if s.Up {
+			return crossed(closes[n-2], closes[n-1], upper[n-2], upper[n-1], true)
+		}
+		return crossed(closes[n-2], closes[n-1], lower[n-2], lower[n-1], false)
+	}
+	return false
+}
+
+func (s Signal) String() string {
+	direction := "↓"
+	if s.Up {
+		direction = "↑"
+	}
+
+	switch s.Kind {
+	case KindRSI:
+		return fmt.Sprintf("RSI(%d) %s %s · %s", s.Period, direction, strconv.FormatFloat(s.Level, 'f', -1, 64), s.Interval)
+	case KindEMACross:
+		return fmt.Sprintf("EMA(%d) %s EMA(%d) · %s", s.Period, direction, s.Slow, s.Interval)
+	case KindSMACross:
+		return fmt.Sprintf("SMA(%d) %s SMA(%d) · %s", s.Period, direction, s.Slow, s.Interval)
+	case KindMACD:
+		return fmt.Sprintf("MACD(12,26,9) %s signal · %s", direction, s.Interval)
+	default:
+		band := "lower"
+		if s.Up {
+			band = "upper"
+		}
+		return fmt.Sprintf("BB(%d,%s) %s %s · %s", s.Period, strconv.FormatFloat(s.Level, 'f', -1, 64), direction, band, s.Interval)
+	}
+}
+
+func crossed(previous, current, previousLevel, currentLevel float64, up bool) bool {
+	if math.IsNaN(previous) || math.IsNaN(current) || math.IsNaN(previousLevel) || math.IsNaN(currentLevel) {
+		return false
+	}
+	if up {
+		return previous <= previousLevel && current > currentLevel
+	}
+	return previous >= previousLevel && current < currentLevel
+}
+
+func parsePeriod(text string) (int, error) {
+	period, err := strconv.Atoi(text)
+	if err != nil || period < 2 || period > 200 {
+		return 0, ErrInvalidSignal
+	}
+	return period, nil
+}
+
+func parseDirection(text, up, down string) (bool, error) {
+	switch strings.ToLower(text) {
+	case up:
+		return true, nil
+	case down:
+		return false, nil
+	}
+	return false, ErrInvalidSignal
+}
@@ -0,0 +1,122 @@
+package indicators
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		text     string
+		want     Signal
+		lookback int
+	}{
+		{"15m rsi 14 above 70", Signal{Kind: KindRSI, Interval: "15m", Period: 14, Level: 70, Up: true}, 141},
+		{"1H RSI 14 below 30", Signal{Kind: KindRSI, Interval: "1h", Period: 14, Level: 30}, 141},
+		{"5m ema 9 21 up", Signal{Kind: KindEMACross, Interval: "5m", Period: 9, Slow: 21, Up: true}, 85},
+		{"1h sma 50 200 down", Signal{Kind: KindSMACross, Interval: "1h", Period: 50, Slow: 200}, 201},
+		{"15m macd up", Signal{Kind: KindMACD, Interval: "15m", Period: 12, Slow: 26, Up: true}, 113},
+		{"1h bollinger 20 2 up", Signal{Kind: KindBollinger, Interval: "1h", Period: 20, Level: 2, Up: true}, 21},
+		{"1h bb 20 2.5 down", Signal{Kind: KindBollinger, Interval: "1h", Period: 20, Level: 2.5}, 21},
+		{"1d rsi 200 above 70", Signal{Kind: KindRSI, Interval: "1d", Period: 200, Level: 70, Up: true}, maxLookback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			signal, err := ParseSignal(strings.Fields(tt.text))
+			if err != nil {
+				t.Fatalf("ParseSignal: %v", err)
+			}
+			if signal != tt.want {
+				t.Errorf("got %+v, want %+v", signal, tt.want)
+			}
+			if got := signal.Lookback(); got != tt.lookback {
+				t.Errorf("Lookback = %d, want %d", got, tt.lookback)
+			}
+			if again, err := ParseSignal(strings.Fields(signal.Condition())); err != nil || again != signal {
+				t.Errorf("Condition %q reads back as %+v, %v", signal.Condition(), again, err)
+			}
+		})
+	}
+
+	invalid := []string{
+		"", "15m", "7m rsi 14 above 70", "15m rsi 14 above 100", "15m rsi 1 above 70",
+		"15m rsi 14 over 70", "5m ema 21 9 up", "5m ema 9 9 up", "5m ema 9 up",
+		"15m macd", "1h bollinger 20 0 up", "1h bollinger 20 2", "1h vwap 20 up",
+	}
+	for _, text := range invalid {
+		if signal, err := ParseSignal(strings.Fields(text)); err == nil {
+			t.Errorf("ParseSignal(%q) = %+v, want an error", text, signal)
+		}
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name                                           string
+		previous, current, previousLevel, currentLevel float64
+		up                                             bool
+		want                                           bool
+	}{
+		{"crosses up", 69, 71, 70, 70, true, true},
+		{"from the level up", 70, 70.1, 70, 70, true, true},
+		{"up to the level", 69, 70, 70, 70, true, false},
+		{"already above", 71, 72, 70, 70, true, false},
+		{"crosses down", 31, 29, 30, 30, false, true},
+		{"from the level down", 30, 29.9, 30, 30, false, true},
+		{"already below", 29, 28, 30, 30, false, false},
+		{"moving level", 10, 11, 10.5, 10.9, true, true},
+		{"level moves past", 10, 11, 9.5, 11.5, true, false},
+		{"not enough history", nan, 71, 70, 70, true, false},
+		{"level not ready", 69, 71, nan, 70, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crossed(tt.previous, tt.current, tt.previousLevel, tt.currentLevel, tt.up); got != tt.want {
+				t.Errorf("crossed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriggered(t *testing.T) {
+	flat := func(n int, value float64) []float64 {
+		closes := make([]float64, n)
+		for i := range closes {
+			closes[i] = value
+		}
+		return closes
+	}
+
+	tests := []struct {
+		name   string
+		signal string
+		closes []float64
+		want   bool
+	}{
+		{"rsi leaves 50 for 100", "1m rsi 14 above 70", append(flat(15, 10), 11), true},
+		{"rsi still flat", "1m rsi 14 above 70", flat(16, 10), false},
+		{"rsi wrong direction", "1m rsi 14 below 30", append(flat(15, 10), 11), false},
+		{"sma cross up on the last candle", "1m sma 2 3 up", []float64{5, 4, 3, 2, 1, 1, 5}, true},
+		{"sma before the cross", "1m sma 2 3 up", []float64{5, 4, 3, 2, 1, 1}, false},
+		{"sma one candle after the cross", "1m sma 2 3 up", []float64{5, 4, 3, 2, 1, 1, 5, 6}, false},
+		{"sma cross down", "1m sma 2 3 down", []float64{1, 2, 3, 4, 5, 5, 1}, true},
+		{"ema cross up", "1m ema 2 5 up", append(flat(10, 10), 9, 9, 9, 20), true},
+		{"bollinger breaks the upper band", "1m bb 20 2 up", append(flat(20, 10), 12), true},
+		{"bollinger stays inside", "1m bb 20 2 up", append(flat(20, 10), 10), false},
+		{"bollinger breaks the lower band", "1m bb 20 2 down", append(flat(20, 10), 8), true},
+		{"too few candles", "1m rsi 14 above 70", []float64{10}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal, err := ParseSignal(strings.Fields(tt.signal))
+			if err != nil {
+				t.Fatalf("ParseSignal: %v", err)
+			}
+			if got := signal.Triggered(tt.closes); got != tt.want {
+				t.Errorf("Triggered = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package monitor
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/templates"
+	"github.com/agopankov/imPulse/client/internal/tracker"
+	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	tele "gopkg.in/telebot.v3"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// indicatorFetches caps the kline requests one indicator check has in flight.
+const indicatorFetches = 8
+
+// indicatorWatcher runs the indicator checks next to the monitor loop. An
+// "all" subscription needs klines for every tracked coin, which would hold
+// the pump ticks back; a minute that comes while a check still runs is
+// skipped.
+type indicatorWatcher struct {
+	running atomic.Bool
+}
+
+type klinesKey struct {
+	symbol   string
+	interval string
+	limit    int
+}
+
+func (w *indicatorWatcher) check(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
+	if len(usr.Indicators.List()) == 0 {
+		return
+	}
+	if !w.running.CompareAndSwap(false, true) {
+		log.Printf("Indicator check for %d still running, skipping this minute", usr.GetFirstChatID())
+		return
+	}
+
+	go func() {
+		defer w.running.Store(false)
+		processIndicators(ctx, secondTelegramClient, binanceClient, usr, trackerInstance)
+	}()
+}
+
+func processIndicators(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
+	subscriptions := usr.Indicators.List()
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	style := usr.GetMessageStyle()
+	lang := usr.GetLanguage()
+	secondThreadOptions := &tele.SendOptions{ThreadID: usr.GetSecondThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+
+	var trackedSymbols []string
+	for symbol := range trackerInstance.GetTrackedSymbols() {
+		trackedSymbols = append(trackedSymbols, symbol)
+	}
+	sort.Strings(trackedSymbols)
+
+	symbols := make([][]string, len(subscriptions))
+	var keys []klinesKey
+	seen := make(map[klinesKey]bool)
+	for i, subscription := range subscriptions {
+		symbols[i] = []string{subscription.Symbol}
+		if subscription.Symbol == "" {
+			symbols[i] = trackedSymbols
+		}
+		for _, symbol := range symbols[i] {
+			key := klinesKey{symbol: symbol, interval: subscription.Signal.Interval, limit: subscription.Signal.Lookback()}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	klinesCache := fetchKlines(ctx, binanceClient, keys)
+	if ctx.Err() != nil {
+		return
+	}
+
+	var alerts []string
+	for i, subscription := range subscriptions {
+		for _, symbol := range symbols[i] {
+			klines := klinesCache[klinesKey{symbol: symbol, interval: subscription.Signal.Interval, limit: subscription.Signal.Lookback()}]
+			if len(klines) == 0 {
+				continue
+			}
+
+			closes := make([]float64, 0, len(klines))
+			for _, kline := range klines {
+				closes = append(closes, kline.Close)
+			}
+
+			last := klines[len(klines)-1]
+			if !subscription.Signal.Triggered(closes) || !usr.Indicators.MarkFired(subscription.ID, symbol, last.OpenTime) {
+				continue
+			}
+
+			log.Printf("Indicator #%d triggered for %s: %s", subscription.ID, symbol, subscription.Signal)
+			alerts = append(alerts, templates.RenderIndicatorAlert(style, lang, templates.IndicatorAlert{
+				Symbol:    symbol,
+				Price:     last.Close,
+				Condition: subscription.Signal.String(),
+			}))
+		}
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+	if usr.QuietHours.IsActive(time.Now()) {
+		usr.QuietHours.Hold(user.HeldIndicator, alerts...)
+		return
+	}
+
+	recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+	if _, err := secondTelegramClient.SendLongMessage(recipient, strings.Join(alerts, ""), secondThreadOptions); err != nil {
+		log.Printf("Error sending indicator alerts: %v\n", err)
+	}
+}
+
+// fetchKlines gets the closed klines for every key, at most indicatorFetches
+// at a time. Keys that failed are left out.
+func fetchKlines(ctx context.Context, binanceClient proto.BinanceServiceClient, keys []klinesKey) map[klinesKey][]*proto.Kline {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[klinesKey][]*proto.Kline, len(keys))
+		slots   = make(chan struct{}, indicatorFetches)
+	)
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key klinesKey) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			klines, err := closedKlines(binanceClient, key.symbol, key.interval, key.limit)
+			if err != nil {
+				log.Printf("Error getting klines for %s: %v", key.symbol, err)
+				return
+			}
+			mu.Lock()
+			results[key] = klines
+			mu.Unlock()
+		}(key)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/templates"
+	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	tele "gopkg.in/telebot.v3"
+	"log"
+	"strconv"
+	"time"
+)
+
+const (
+	listingReconnectDelay = 10 * time.Second
+	// listingSeqHeader is the stream header with the seq of the server's
+	// last listing event.
+	listingSeqHeader = "listing-seq"
+)
+
+// listingWatcher keeps a listing event stream open while the user wants
+// listing notifications. After a reconnect it asks for the events after the
+// last seq it saw, so nothing the server still remembers is missed.
+type listingWatcher struct {
+	cancel context.CancelFunc
+}
+
+func (w *listingWatcher) sync(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User) {
+	if !usr.Listings.IsEnabled() {
+		if w.cancel != nil {
+			w.cancel()
+			w.cancel = nil
+		}
+		return
+	}
+	if w.cancel != nil {
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	go streamListings(streamCtx, secondTelegramClient, binanceClient, usr)
+}
+
+func streamListings(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User) {
+	var seq int64
+	for {
+		err := receiveListings(ctx, secondTelegramClient, binanceClient, usr, &seq)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Listing stream for %d failed: %v", usr.GetFirstChatID(), err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listingReconnectDelay):
+		}
+	}
+}
+
+func receiveListings(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, seq *int64) error {
+	stream, err := binanceClient.StreamListingEvents(ctx, &proto.ListingEventsRequest{AfterSeq: *seq})
+	if err != nil {
+		return err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return err
+	}
+	// The first stream only brings live events, so it resumes from the last
+	// event at the time it opened. Later ones keep the seq seen: seqs grow
+	// across server restarts, a restarted server replays what it has after
+	// it.
+	if values := header.Get(listingSeqHeader); len(values) > 0 && *seq == 0 {
+		if latest, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+			*seq = latest
+		}
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		*seq = event.Seq
+		// Status changes are mostly the maintenance BREAK every symbol goes
+		// through, nothing to tell the user about.
+		if event.QuoteAsset != "USDT" || event.Kind == "status" {
+			continue
+		}
+
+		log.Printf("Listing %s %s: %s -> %s", event.Kind, event.Symbol, event.PreviousStatus, event.Status)
+		message := templates.RenderListingAlert(usr.GetMessageStyle(), usr.GetLanguage(), templates.ListingAlert{
+			Symbol:         event.Symbol,
+			Kind:           event.Kind,
+			Status:         event.Status,
+			PreviousStatus: event.PreviousStatus,
+		})
+
+		// Listings don't wait for the digest, during quiet hours they are only
+		// sent without a sound.
+		options := &tele.SendOptions{ThreadID: usr.GetSecondThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+		if usr.QuietHours.IsActive(time.Now()) {
+			options = silentOptions(options)
+		}
+		recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+		if _, err := secondTelegramClient.SendLongMessage(recipient, message, options); err != nil {
+			log.Printf("Error sending listing alert: %v\n", err)
+		}
+	}
+}
@@ -2,10 +2,20 @@ package monitor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/agopankov/imPulse/client/internal/baseline"
+	"github.com/agopankov/imPulse/client/internal/chart"
+	"github.com/agopankov/imPulse/client/internal/database"
+	"github.com/agopankov/imPulse/client/internal/futures"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/client/internal/rules"
+	"github.com/agopankov/imPulse/client/internal/strategy"
 	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/templates"
 	"github.com/agopankov/imPulse/client/internal/tracker"
 	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/client/internal/venue"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
 	tele "gopkg.in/telebot.v3"
 	"log"
@@ -15,6 +25,21 @@ import (
 	"time"
 )
 
+const (
+	chartLookback = 30 * time.Minute
+	chartMinutes  = 120
+)
+
+const (
+	futuresOpenInterestSymbols = 40
+	futuresSeedSymbols         = 10
+	openInterestHistoryPeriod  = 5 * time.Minute
+	maxOpenInterestHistory     = 500
+	// liquidityRecheck is how long a pump alert held back for a thin book
+	// waits before the book is fetched again.
+	liquidityRecheck = time.Minute
+)
+
 type Monitor struct {
 	TelegramClient       *telegram.Client
 	SecondTelegramClient *telegram.Client
@@ -33,10 +58,19 @@ func getPriceForSymbol(symbol string, prices []*proto.USDTPrice) string {
 	return ""
 }
 
-func PriceChanges(ctx context.Context, client *telegram.Client, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
+func PriceChanges(ctx context.Context, client *telegram.Client, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, futuresClient proto.FuturesServiceClient, db database.Database, usr *user.User, trackerInstance *tracker.Tracker) {
 	ticker := time.NewTicker(5 * time.Second)
 	notifyTicker := time.NewTicker(1 * time.Minute)
 	logTicker := time.NewTicker(2 * time.Second)
+	indicatorTicker := time.NewTicker(1 * time.Minute)
+	futuresTicker := time.NewTicker(1 * time.Minute)
+	whaleTicker := time.NewTicker(1 * time.Minute)
+	listingTicker := time.NewTicker(1 * time.Minute)
+	history := rules.NewHistory(time.Hour + time.Minute)
+	futuresScanner := futures.NewScanner()
+	indicators := &indicatorWatcher{}
+	whales := &whaleWatcher{}
+	listings := &listingWatcher{}
 
 	for {
 		select {
@@ -45,30 +79,46 @@ func PriceChanges(ctx context.Context, client *telegram.Client, secondTelegramCl
 		case <-logTicker.C:
 			processLogTicker(trackerInstance)
 		case <-ticker.C:
-			processTicker(client, secondTelegramClient, binanceClient, usr, trackerInstance)
+			processTicker(client, secondTelegramClient, binanceClient, usr, trackerInstance, history)
 		case <-notifyTicker.C:
-			processNotifyTicker(client, binanceClient, usr, trackerInstance)
+			processNotifyTicker(client, binanceClient, db, usr, trackerInstance)
+		case <-indicatorTicker.C:
+			indicators.check(ctx, secondTelegramClient, binanceClient, usr, trackerInstance)
+		case <-futuresTicker.C:
+			if usr.Futures.IsEnabled() {
+				processFuturesTicker(secondTelegramClient, futuresClient, usr, trackerInstance, futuresScanner)
+			}
+		case <-whaleTicker.C:
+			whales.sync(ctx, secondTelegramClient, binanceClient, usr, trackerInstance)
+		case <-listingTicker.C:
+			listings.sync(ctx, secondTelegramClient, binanceClient, usr)
 		}
 	}
 }
 
-func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
+func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker, history *rules.History) {
 	chatID := usr.GetFirstChatID()
 	secondChatID := usr.GetSecondChatID()
-
-	ctx := context.Background()
-	usdtPrices, err := binanceClient.GetUSDTPrices(ctx, &proto.Empty{})
-	if err != nil {
-		log.Printf("Error getting USDT prices: %v", err)
-		return
+	style := usr.GetMessageStyle()
+	lang := usr.GetLanguage()
+	threadOptions := &tele.SendOptions{ThreadID: usr.GetFirstThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+	secondThreadOptions := &tele.SendOptions{ThreadID: usr.GetSecondThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+	quiet := usr.QuietHours.IsActive(time.Now())
+
+	if !quiet {
+		sendQuietDigest(telegramClient, secondTelegramClient, usr, threadOptions, secondThreadOptions)
 	}
 
-	changePercent, err := binanceClient.Get24HChangePercent(ctx, &proto.Empty{})
-	if err != nil {
-		log.Printf("Error getting 24h change percent: %v", err)
+	usdtPrices, changePercent, ok := fetchMarket(binanceClient, usr.Exchanges.Get())
+	if !ok {
 		return
 	}
 
+	processRules(secondTelegramClient, usr, history, usdtPrices.Prices, changePercent.ChangePercents, quiet, secondThreadOptions)
+
+	settings := strategySettings(usr)
+	now := time.Now()
+
 	var newTrackedSymbols []tracker.SymbolChange
 	for _, price := range usdtPrices.Prices {
 		change := 0.0
@@ -79,14 +129,8 @@ func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegr
 			}
 		}
 
-		if change >= usr.ChangePercent24.GetPercent() && !trackerInstance.IsTracked(price.Symbol) {
-			newSymbol := tracker.SymbolChange{
-				Symbol:           price.Symbol,
-				PriceChange:      fmt.Sprintf("%.8f", price.Price),
-				FirstPriceChange: fmt.Sprintf("%.8f", price.Price),
-				PriceChangePct:   change,
-				AddedAt:          time.Now(),
-			}
+		if strategy.ShouldTrack(settings, change) && !trackerInstance.IsTracked(price.Symbol) {
+			newSymbol := strategy.NewSymbol(price.Symbol, price.Price, change, now)
 			trackerInstance.UpdateTrackedSymbol(newSymbol)
 			newTrackedSymbols = append(newTrackedSymbols, newSymbol)
 		}
@@ -96,18 +140,24 @@ func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegr
 		return newTrackedSymbols[i].PriceChangePct > newTrackedSymbols[j].PriceChangePct
 	})
 
-	var messageBuilder strings.Builder
+	var alerts []string
 	for _, symbolChange := range newTrackedSymbols {
-		emoji := "✅"
-		price := strings.TrimRight(strings.TrimRight(symbolChange.PriceChange, "0"), ".")
-		message := fmt.Sprintf("%s %s / USDT P: %s Ch24h: %.2f%% \n", emoji, symbolChange.Symbol[:len(symbolChange.Symbol)-4], price, symbolChange.PriceChangePct)
-		messageBuilder.WriteString(message)
+		price, _ := strconv.ParseFloat(symbolChange.PriceChange, 64)
+		message := templates.RenderSymbolLine(style, lang, templates.SymbolLine{
+			Symbol:    symbolChange.Symbol,
+			Price:     price,
+			Change24h: symbolChange.PriceChangePct,
+			Trend:     templates.TrendNew,
+		})
+		alerts = append(alerts, message)
 	}
 
-	if messageBuilder.Len() > 0 {
-		message := messageBuilder.String()
-		recipient := &tele.User{ID: chatID}
-		_, err := telegramClient.SendMessage(recipient, message)
+	if len(alerts) > 0 && quiet {
+		usr.QuietHours.Hold(user.HeldNewCoins, alerts...)
+	} else if len(alerts) > 0 {
+		message := strings.Join(alerts, "")
+		recipient := &tele.Chat{ID: chatID}
+		_, err := telegramClient.SendLongMessage(recipient, message, threadOptions)
 		if err != nil {
 			log.Printf("Error sending message: %v\n", err)
 		}
@@ -122,7 +172,7 @@ func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegr
 			}
 		}
 
-		if change24h <= usr.ChangePercent24.GetPercent() {
+		if strategy.ShouldUntrack(settings, change24h) {
 			trackerInstance.RemoveTrackedSymbol(symbol)
 			continue
 		}
@@ -139,32 +189,72 @@ func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegr
 		currentPrice := getPriceForSymbol(symbolChange.Symbol, usdtPrices.Prices)
 
 		currentPriceFloat, _ := strconv.ParseFloat(currentPrice, 64)
-		previousPriceFloat, _ := strconv.ParseFloat(symbolChange.FirstPriceChange, 64)
+		if currentPriceFloat > 0 {
+			trackerInstance.RecordPrice(symbolChange.Symbol, currentPriceFloat, now)
+		}
+		refreshVWAP(binanceClient, trackerInstance, settings, &symbolChange, now)
+		previousPriceFloat := strategy.Baseline(settings, symbolChange, trackerInstance.PriceHistory(symbolChange.Symbol), now)
+		pumpPct := strategy.PumpPercent(previousPriceFloat, currentPriceFloat)
+
+		if strategy.RearmOnRetrace(settings, &symbolChange, currentPriceFloat, now) {
+			log.Printf("Re-armed %s after retrace to %.7f", symbolChange.Symbol, currentPriceFloat)
+			trackerInstance.UpdateTrackedSymbol(symbolChange)
+			continue
+		}
 
-		if !symbolChange.NotificationOfPump && time.Since(symbolChange.AddedAt) <= usr.PumpSettings.GetWaitTime() && (currentPriceFloat/previousPriceFloat)-1 >= usr.PumpSettings.GetPumpPercent()/100 {
+		if strategy.ShouldAlertPump(settings, symbolChange, pumpPct, now) {
 			log.Printf("Pump %s, current pump persent %.5f%%, firstPrice: %.7f, currentPrice: %.7f, notification: %t",
 				symbolChange.Symbol[:len(symbolChange.Symbol)-4],
 				((currentPriceFloat/previousPriceFloat)-1)*100,
 				previousPriceFloat,
 				currentPriceFloat,
 				symbolChange.NotificationOfPump)
-			message := fmt.Sprintf("🚀 %s / USDT P: %.7f Ch24h: %.2f%% (PrP: %.7f) \n",
-				symbolChange.Symbol[:len(symbolChange.Symbol)-4],
-				currentPriceFloat,
-				symbolChange.PriceChangePct,
-				previousPriceFloat,
-			)
-			messageBuilder.WriteString(message)
+			// A held back alert isn't marked as sent, so it still goes out once
+			// the book fills up.
+			minDepth := usr.Liquidity.GetMinDepth()
+			if minDepth > 0 && now.Sub(symbolChange.SuppressedAt) < liquidityRecheck {
+				continue
+			}
+			liquidity, complete := orderBookLiquidity(binanceClient, symbolChange.Symbol, usr.Liquidity.GetBand())
+			if liquidity != nil && complete && minDepth > 0 && liquidity.BidDepth+liquidity.AskDepth < minDepth {
+				log.Printf("Suppressed pump alert for %s, %.0f USDT within ±%.2f%% is below %.0f", symbolChange.Symbol, liquidity.BidDepth+liquidity.AskDepth, liquidity.Band, minDepth)
+				symbolChange.SuppressedAt = now
+				trackerInstance.UpdateTrackedSymbol(symbolChange)
+				continue
+			}
 
-			recipient := &tele.User{ID: secondChatID}
-			_, err := secondTelegramClient.SendMessage(recipient, message)
+			message := templates.RenderPumpAlert(style, lang, templates.PumpAlert{
+				Symbol:        symbolChange.Symbol,
+				Price:         currentPriceFloat,
+				Change24h:     symbolChange.PriceChangePct,
+				BaselinePrice: previousPriceFloat,
+				Liquidity:     liquidity,
+			})
+
+			if quiet && pumpPct < usr.QuietHours.GetUrgentPercent() {
+				usr.QuietHours.Hold(user.HeldPump, message)
+				strategy.MarkPumpAlerted(&symbolChange, pumpPct, currentPriceFloat, now)
+				trackerInstance.UpdateTrackedSymbol(symbolChange)
+				continue
+			}
+
+			options := secondThreadOptions
+			if quiet {
+				options = silentOptions(secondThreadOptions)
+			}
+
+			recipient := &tele.Chat{ID: secondChatID}
+			err := sendPumpAlert(secondTelegramClient, binanceClient, recipient, symbolChange, previousPriceFloat, message, options, now)
 			if err != nil {
 				log.Printf("Error sending message to the second chat: %v\n", err)
 			} else {
-				symbolChange.NotificationOfPump = true
+				strategy.MarkPumpAlerted(&symbolChange, pumpPct, currentPriceFloat, now)
 				trackerInstance.UpdateTrackedSymbol(symbolChange)
 			}
 		} else {
+			if strategy.TrackPeak(&symbolChange, currentPriceFloat) {
+				trackerInstance.UpdateTrackedSymbol(symbolChange)
+			}
 			log.Printf("Don't pump %s, current pump persent %.5f%%, notification: %t",
 				symbolChange.Symbol[:len(symbolChange.Symbol)-4],
 				((currentPriceFloat/previousPriceFloat)-1)*100,
@@ -174,19 +264,161 @@ func processTicker(telegramClient *telegram.Client, secondTelegramClient *telegr
 
 }
 
-func processNotifyTicker(telegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
-	chatID := usr.GetFirstChatID()
+func processRules(secondTelegramClient *telegram.Client, usr *user.User, history *rules.History, prices []*proto.USDTPrice, changePercents []*proto.ChangePercent, quiet bool, secondThreadOptions *tele.SendOptions) {
+	list := usr.Rules.List()
+	if len(list) == 0 {
+		return
+	}
+
+	now := time.Now()
+	tickers := make(map[string]*proto.ChangePercent, len(changePercents))
+	for _, changePercent := range changePercents {
+		tickers[changePercent.Symbol] = changePercent
+	}
 
+	style := usr.GetMessageStyle()
+	lang := usr.GetLanguage()
+	snapshotPrices := make(map[string]float64, len(prices))
+	var alerts []string
+	for _, price := range prices {
+		snapshotPrices[price.Symbol] = price.Price
+		ticker, ok := tickers[price.Symbol]
+		if !ok || price.Price <= 0 {
+			continue
+		}
+
+		snapshot := history.Snapshot(price.Symbol, price.Price, ticker.ChangePercent, ticker.QuoteVolume, now)
+		for _, rule := range list {
+			if !usr.Rules.Match(rule.ID, price.Symbol, rule.Expr.Eval(snapshot), now) {
+				continue
+			}
+
+			log.Printf("Rule #%d matched %s", rule.ID, price.Symbol)
+			alerts = append(alerts, templates.RenderRuleAlert(style, lang, templates.RuleAlert{
+				Symbol:    price.Symbol,
+				Price:     price.Price,
+				Change24h: ticker.ChangePercent,
+				RuleID:    rule.ID,
+				Rule:      rule.Text,
+			}))
+		}
+	}
+	history.Record(now, snapshotPrices)
+
+	if len(alerts) == 0 {
+		return
+	}
+	if quiet {
+		usr.QuietHours.Hold(user.HeldRule, alerts...)
+		return
+	}
+
+	recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+	if _, err := secondTelegramClient.SendLongMessage(recipient, strings.Join(alerts, ""), secondThreadOptions); err != nil {
+		log.Printf("Error sending rule alerts: %v\n", err)
+	}
+}
+
+// fetchMarket merges prices and 24h changes from every exchange the user
+// monitors, naming pairs with venue.Symbol. A venue that fails is skipped.
+func fetchMarket(binanceClient proto.BinanceServiceClient, exchanges []string) (*proto.USDTPricesResponse, *proto.ChangePercentResponse, bool) {
 	ctx := context.Background()
-	usdtPrices, err := binanceClient.GetUSDTPrices(ctx, &proto.Empty{})
+	usdtPrices := &proto.USDTPricesResponse{}
+	changePercent := &proto.ChangePercentResponse{}
+	fetched := false
+
+	for _, exchange := range exchanges {
+		request := &proto.MarketRequest{Exchange: exchange}
+		prices, err := binanceClient.GetUSDTPrices(ctx, request)
+		if err != nil {
+			log.Printf("Error getting USDT prices from %s: %v", exchange, err)
+			continue
+		}
+
+		changes, err := binanceClient.Get24HChangePercent(ctx, request)
+		if err != nil {
+			log.Printf("Error getting 24h change percent from %s: %v", exchange, err)
+			continue
+		}
+
+		for _, price := range prices.Prices {
+			price.Symbol = venue.Symbol(exchange, price.Symbol)
+			usdtPrices.Prices = append(usdtPrices.Prices, price)
+		}
+		for _, change := range changes.ChangePercents {
+			change.Symbol = venue.Symbol(exchange, change.Symbol)
+			changePercent.ChangePercents = append(changePercent.ChangePercents, change)
+		}
+		fetched = true
+	}
+	return usdtPrices, changePercent, fetched
+}
+
+func strategySettings(usr *user.User) strategy.Settings {
+	return strategy.Settings{
+		ChangePercent24: usr.ChangePercent24.GetPercent(),
+		PumpPercent:     usr.PumpSettings.GetPumpPercent(),
+		WaitTime:        usr.PumpSettings.GetWaitTime(),
+		Cooldown:        usr.PumpSettings.GetCooldown(),
+		Steps:           usr.PumpSettings.GetSteps(),
+		Retrace:         usr.PumpSettings.GetRetrace(),
+		Baseline:        usr.PumpSettings.GetBaseline(),
+	}
+}
+
+func refreshVWAP(binanceClient proto.BinanceServiceClient, trackerInstance *tracker.Tracker, settings strategy.Settings, symbolChange *tracker.SymbolChange, now time.Time) {
+	if settings.Baseline.Mode != baseline.ModeVWAP || now.Sub(symbolChange.VWAPAt) < time.Minute {
+		return
+	}
+
+	vwap, err := fetchVWAP(binanceClient, symbolChange.Symbol, now, settings.Baseline.Window)
 	if err != nil {
-		log.Printf("Error getting USDT prices: %v", err)
+		log.Printf("Error getting VWAP for %s: %v", symbolChange.Symbol, err)
 		return
 	}
+	symbolChange.VWAP = vwap
+	symbolChange.VWAPAt = now
+	trackerInstance.UpdateTrackedSymbol(*symbolChange)
+}
 
-	changePercent, err := binanceClient.Get24HChangePercent(ctx, &proto.Empty{})
+func fetchVWAP(binanceClient proto.BinanceServiceClient, symbol string, now time.Time, window time.Duration) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exchange, pair := venue.Split(symbol)
+	klines, err := binanceClient.GetKlines(ctx, &proto.KlinesRequest{
+		Exchange:  exchange,
+		Symbol:    pair,
+		Interval:  "1m",
+		StartTime: now.Add(-window).UnixMilli(),
+		Limit:     int32(window/time.Minute) + 1,
+	})
 	if err != nil {
-		log.Printf("Error getting 24h change percent: %v", err)
+		return 0, err
+	}
+
+	prices := make([]float64, 0, len(klines.Klines))
+	volumes := make([]float64, 0, len(klines.Klines))
+	for _, kline := range klines.Klines {
+		prices = append(prices, (kline.High+kline.Low+kline.Close)/3)
+		volumes = append(volumes, kline.Volume)
+	}
+
+	vwap, ok := baseline.VWAP(prices, volumes)
+	if !ok {
+		return 0, errors.New("no volume in the VWAP window")
+	}
+	return vwap, nil
+}
+
+func processNotifyTicker(telegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, db database.Database, usr *user.User, trackerInstance *tracker.Tracker) {
+	chatID := usr.GetFirstChatID()
+	style := usr.GetMessageStyle()
+	lang := usr.GetLanguage()
+	threadOptions := &tele.SendOptions{ThreadID: usr.GetFirstThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+
+	usdtPrices, changePercent, ok := fetchMarket(binanceClient, usr.Exchanges.Get())
+	if !ok {
 		return
 	}
 
@@ -201,8 +433,11 @@ func processNotifyTicker(telegramClient *telegram.Client, binanceClient proto.Bi
 	})
 
 	var messageBuilder strings.Builder
+	var entries []string
+	var symbols []string
 	for _, symbolChange := range sortedSymbols {
 		currentPrice := getPriceForSymbol(symbolChange.Symbol, usdtPrices.Prices)
+		symbols = append(symbols, symbolChange.Symbol)
 
 		var change24h float64
 		for _, changePercentData := range changePercent.ChangePercents {
@@ -212,29 +447,48 @@ func processNotifyTicker(telegramClient *telegram.Client, binanceClient proto.Bi
 			}
 		}
 
-		price := strings.TrimRight(strings.TrimRight(currentPrice, "0"), ".")
-		emoji := ""
+		trend := templates.TrendFlat
 		currentPriceFloat, _ := strconv.ParseFloat(currentPrice, 64)
 		previousPriceFloat, _ := strconv.ParseFloat(symbolChange.PriceChange, 64)
+		firstPriceFloat, _ := strconv.ParseFloat(symbolChange.FirstPriceChange, 64)
 		if currentPriceFloat > previousPriceFloat {
-			emoji = "📈"
+			trend = templates.TrendUp
 		} else if currentPriceFloat < previousPriceFloat {
-			emoji = "📉"
-		} else {
-			emoji = "🔹"
+			trend = templates.TrendDown
 		}
 
-		message := fmt.Sprintf("%s %s / USDT P: %s Ch24h: %.2f%% \n", emoji, symbolChange.Symbol[:len(symbolChange.Symbol)-4], price, change24h)
+		message := templates.RenderSymbolLine(style, lang, templates.SymbolLine{
+			Symbol:     symbolChange.Symbol,
+			Price:      currentPriceFloat,
+			Change24h:  change24h,
+			AlertPrice: firstPriceFloat,
+			Trend:      trend,
+		})
 		messageBuilder.WriteString(message)
+		entries = append(entries, message)
 
 		symbolChange.PriceChange = currentPrice
 		trackerInstance.UpdateTrackedSymbol(symbolChange)
 	}
 
+	quiet := usr.QuietHours.IsActive(time.Now())
+	if usr.LiveBoard.IsEnabled() {
+		if quiet {
+			threadOptions = silentOptions(threadOptions)
+		}
+		updateLiveBoard(telegramClient, db, usr, chatID, threadOptions, entries, symbols)
+		return
+	}
+
+	if quiet {
+		usr.QuietHours.HoldSummary(messageBuilder.String())
+		return
+	}
+
 	if messageBuilder.Len() > 0 {
 		message := messageBuilder.String()
-		recipient := &tele.User{ID: chatID}
-		_, err := telegramClient.SendMessage(recipient, message)
+		recipient := &tele.Chat{ID: chatID}
+		_, err := telegramClient.SendLongMessage(recipient, message, threadOptions)
 		if err != nil {
 			log.Printf("Error sending message: %v\n", err)
 		}
@@ -242,6 +496,351 @@ func processNotifyTicker(telegramClient *telegram.Client, binanceClient proto.Bi
 
 }
 
+func processFuturesTicker(secondTelegramClient *telegram.Client, futuresClient proto.FuturesServiceClient, usr *user.User, trackerInstance *tracker.Tracker, scanner *futures.Scanner) {
+	style := usr.GetMessageStyle()
+	lang := usr.GetLanguage()
+	secondThreadOptions := &tele.SendOptions{ThreadID: usr.GetSecondThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+	settings := usr.Futures.GetSettings()
+	now := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	markPrices, err := futuresClient.GetMarkPrices(ctx, &proto.Empty{})
+	if err != nil {
+		log.Printf("Error getting futures mark prices: %v", err)
+		return
+	}
+
+	changePercent, err := futuresClient.GetFutures24HChangePercent(ctx, &proto.Empty{})
+	if err != nil {
+		log.Printf("Error getting futures 24h change percent: %v", err)
+		return
+	}
+
+	var alerts []string
+	prices := make(map[string]float64, len(markPrices.MarkPrices))
+	for _, markPrice := range markPrices.MarkPrices {
+		prices[markPrice.Symbol] = markPrice.MarkPrice
+		if !scanner.ExtremeFunding(markPrice.Symbol, markPrice.FundingRate, settings) {
+			continue
+		}
+
+		log.Printf("Funding rate of %s crossed the threshold: %f", markPrice.Symbol, markPrice.FundingRate)
+		alert := templates.FundingAlert{
+			Symbol:      markPrice.Symbol,
+			MarkPrice:   markPrice.MarkPrice,
+			FundingRate: markPrice.FundingRate,
+			NextFunding: time.UnixMilli(markPrice.NextFundingTime).Sub(now),
+		}
+		alert.SettledRate, alert.Settled = settledFundingRate(futuresClient, markPrice.Symbol)
+		alerts = append(alerts, templates.RenderFundingAlert(style, lang, alert))
+	}
+
+	tickers := make([]futures.Ticker, 0, len(changePercent.ChangePercents))
+	changes := make(map[string]float64, len(changePercent.ChangePercents))
+	for _, change := range changePercent.ChangePercents {
+		if strings.HasSuffix(change.Symbol, "USDT") {
+			tickers = append(tickers, futures.Ticker{Symbol: change.Symbol, ChangePercent: change.ChangePercent})
+			changes[change.Symbol] = change.ChangePercent
+		}
+	}
+
+	var tracked []string
+	for symbol := range trackerInstance.GetTrackedSymbols() {
+		if exchange, _ := venue.Split(symbol); exchange == venue.Default {
+			tracked = append(tracked, symbol)
+		}
+	}
+	sort.Strings(tracked)
+
+	candidates := futures.Candidates(tickers, tracked, futuresOpenInterestSymbols)
+	if len(candidates) > 0 {
+		openInterests, err := futuresClient.GetOpenInterest(ctx, &proto.OpenInterestRequest{Symbols: candidates})
+		if err != nil {
+			log.Printf("Error getting open interest: %v", err)
+		} else {
+			seeded := 0
+			for _, openInterest := range openInterests.OpenInterests {
+				if !scanner.Seen(openInterest.Symbol) && seeded < futuresSeedSymbols {
+					seedOpenInterest(futuresClient, scanner, openInterest.Symbol, settings.OpenInterestWindow, now)
+					seeded++
+				}
+				scanner.RecordOpenInterest(openInterest.Symbol, openInterest.OpenInterest, now, settings.OpenInterestWindow)
+				spike, ok := scanner.OpenInterestSpike(openInterest.Symbol, settings, now)
+				if !ok {
+					continue
+				}
+
+				log.Printf("Open interest spike for %s: %f%%", openInterest.Symbol, spike.Change)
+				alerts = append(alerts, templates.RenderOpenInterestAlert(style, lang, templates.OpenInterestAlert{
+					Symbol:       openInterest.Symbol,
+					Price:        prices[openInterest.Symbol],
+					Change:       spike.Change,
+					Window:       settings.OpenInterestWindow,
+					From:         spike.From,
+					To:           spike.To,
+					OpenInterest: openInterest.OpenInterestValue,
+					Change24h:    changes[openInterest.Symbol],
+				}))
+			}
+		}
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+	if usr.QuietHours.IsActive(now) {
+		usr.QuietHours.Hold(user.HeldFutures, alerts...)
+		return
+	}
+
+	recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+	if _, err := secondTelegramClient.SendLongMessage(recipient, strings.Join(alerts, ""), secondThreadOptions); err != nil {
+		log.Printf("Error sending futures alerts: %v\n", err)
+	}
+}
+
+// orderBookLiquidity is nil for pairs of other venues and when the book
+// can't be fetched, the alert then goes out without it. The server fetches
+// as deep a book as the band needs; complete is false when even that ends
+// inside the band and the depth is only a lower bound.
+func orderBookLiquidity(binanceClient proto.BinanceServiceClient, symbol string, band float64) (*templates.Liquidity, bool) {
+	exchange, pair := venue.Split(symbol)
+	if exchange != venue.Default {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	book, err := binanceClient.GetOrderBook(ctx, &proto.OrderBookRequest{Symbol: pair, BandPercent: band})
+	if err != nil {
+		log.Printf("Error getting order book for %s: %v", symbol, err)
+		return nil, false
+	}
+	if book.Liquidity == nil || book.Liquidity.MidPrice == 0 {
+		return nil, false
+	}
+	return &templates.Liquidity{
+		Band:      book.Liquidity.BandPercent,
+		BidDepth:  book.Liquidity.BidNotional,
+		AskDepth:  book.Liquidity.AskNotional,
+		Imbalance: book.Liquidity.Imbalance,
+	}, book.Liquidity.Complete
+}
+
+// seedOpenInterest fills the window of a symbol seen for the first time from
+// the 5 minute open interest history, so spikes are caught without waiting
+// for a whole window of polls.
+func seedOpenInterest(futuresClient proto.FuturesServiceClient, scanner *futures.Scanner, symbol string, window time.Duration, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	limit := int32(window/openInterestHistoryPeriod) + 1
+	if limit > maxOpenInterestHistory {
+		limit = maxOpenInterestHistory
+	}
+
+	history, err := futuresClient.GetOpenInterestHistory(ctx, &proto.OpenInterestHistoryRequest{Symbol: symbol, Period: "5m", Limit: limit})
+	if err != nil {
+		log.Printf("Error getting open interest history for %s: %v", symbol, err)
+		return
+	}
+	for _, openInterest := range history.OpenInterests {
+		at := time.UnixMilli(openInterest.Time)
+		if at.Before(now) {
+			scanner.RecordOpenInterest(symbol, openInterest.OpenInterest, at, window)
+		}
+	}
+}
+
+// settledFundingRate is the last funding rate settled for the symbol, the
+// alert goes out without it when it can't be fetched.
+func settledFundingRate(futuresClient proto.FuturesServiceClient, symbol string) (float64, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fundingRates, err := futuresClient.GetFundingRates(ctx, &proto.FundingRatesRequest{Symbol: symbol, Limit: 1})
+	if err != nil {
+		log.Printf("Error getting funding rates for %s: %v", symbol, err)
+		return 0, false
+	}
+	if len(fundingRates.FundingRates) == 0 {
+		return 0, false
+	}
+	return fundingRates.FundingRates[len(fundingRates.FundingRates)-1].FundingRate, true
+}
+
+func closedKlines(binanceClient proto.BinanceServiceClient, symbol string, interval string, limit int) ([]*proto.Kline, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exchange, pair := venue.Split(symbol)
+	klines, err := binanceClient.GetKlines(ctx, &proto.KlinesRequest{
+		Exchange: exchange,
+		Symbol:   pair,
+		Interval: interval,
+		Limit:    int32(limit + 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	closed := klines.Klines
+	for len(closed) > 0 && closed[len(closed)-1].CloseTime >= now {
+		closed = closed[:len(closed)-1]
+	}
+	return closed, nil
+}
+
+// quietMoreKeys count the alerts of each kind a digest had no room for.
+var quietMoreKeys = map[user.HeldKind]i18n.Key{
+	user.HeldNewCoins:  i18n.QuietMoreCoins,
+	user.HeldPump:      i18n.QuietMorePumps,
+	user.HeldRule:      i18n.QuietMoreRules,
+	user.HeldIndicator: i18n.QuietMoreIndicators,
+	user.HeldFutures:   i18n.QuietMoreFutures,
+	user.HeldWhale:     i18n.QuietMoreWhales,
+}
+
+func sendQuietDigest(telegramClient *telegram.Client, secondTelegramClient *telegram.Client, usr *user.User, threadOptions *tele.SendOptions, secondThreadOptions *tele.SendOptions) {
+	first, second, dropped := usr.QuietHours.TakeDigest()
+	header := i18n.T(usr.GetLanguage(), i18n.QuietDigest)
+	for _, kind := range user.HeldKinds {
+		if dropped[kind] == 0 {
+			continue
+		}
+		more := i18n.T(usr.GetLanguage(), quietMoreKeys[kind], dropped[kind])
+		if kind == user.HeldNewCoins {
+			first += more
+		} else {
+			second += more
+		}
+	}
+
+	// In single-bot mode both halves go to the same chat, send them under one
+	// header.
+	if telegramClient == secondTelegramClient && usr.GetFirstChatID() == usr.GetSecondChatID() && threadOptions.ThreadID == secondThreadOptions.ThreadID {
+		first, second = first+second, ""
+	}
+
+	if first != "" {
+		recipient := &tele.Chat{ID: usr.GetFirstChatID()}
+		if _, err := telegramClient.SendLongMessage(recipient, header+first, threadOptions); err != nil {
+			log.Printf("Error sending quiet hours digest: %v\n", err)
+		}
+	}
+
+	if second != "" {
+		recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+		if _, err := secondTelegramClient.SendLongMessage(recipient, header+second, secondThreadOptions); err != nil {
+			log.Printf("Error sending quiet hours digest to the second chat: %v\n", err)
+		}
+	}
+}
+
+func silentOptions(options *tele.SendOptions) *tele.SendOptions {
+	silent := *options
+	silent.DisableNotification = true
+	return &silent
+}
+
+func sendPumpAlert(telegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, recipient tele.Recipient, symbolChange tracker.SymbolChange, baseline float64, message string, options *tele.SendOptions, now time.Time) error {
+	photo, err := renderPumpChart(binanceClient, symbolChange, baseline, now)
+	if err != nil {
+		log.Printf("Error rendering chart for %s: %v\n", symbolChange.Symbol, err)
+		_, err := telegramClient.SendAlert(recipient, message, options)
+		return err
+	}
+
+	_, err = telegramClient.SendPhotoAlert(recipient, photo, message, options)
+	return err
+}
+
+// renderPumpChart draws the minutes up to now, from chartLookback before the
+// coin was tracked or at most chartMinutes back, since step and re-armed
+// alerts can fire hours after tracking began. The marker is the previous
+// alert for a step alert and the start of tracking otherwise.
+func renderPumpChart(binanceClient proto.BinanceServiceClient, symbolChange tracker.SymbolChange, baseline float64, now time.Time) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := symbolChange.AddedAt.Add(-chartLookback)
+	if earliest := now.Add(-chartMinutes * time.Minute); start.Before(earliest) {
+		start = earliest
+	}
+	marker := symbolChange.AddedAt
+	if symbolChange.NotificationOfPump {
+		marker = symbolChange.LastPumpAt
+	}
+
+	exchange, pair := venue.Split(symbolChange.Symbol)
+	klines, err := binanceClient.GetKlines(ctx, &proto.KlinesRequest{
+		Exchange:  exchange,
+		Symbol:    pair,
+		Interval:  "1m",
+		StartTime: start.UnixMilli(),
+		EndTime:   now.UnixMilli(),
+		Limit:     chartMinutes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]chart.Candle, 0, len(klines.Klines))
+	for _, kline := range klines.Klines {
+		candles = append(candles, chart.Candle{
+			Time:  time.UnixMilli(kline.OpenTime),
+			Open:  kline.Open,
+			High:  kline.High,
+			Low:   kline.Low,
+			Close: kline.Close,
+		})
+	}
+
+	return chart.RenderPNG(candles, baseline, marker)
+}
+
+func updateLiveBoard(telegramClient *telegram.Client, db database.Database, usr *user.User, chatID int64, threadOptions *tele.SendOptions, entries []string, symbols []string) {
+	boardChatID, messageID := usr.LiveBoard.GetMessage()
+	hasBoard := boardChatID == chatID && messageID != 0
+
+	if len(entries) == 0 {
+		if hasBoard {
+			entries = []string{i18n.T(usr.GetLanguage(), i18n.LiveBoardEmpty)}
+		} else {
+			return
+		}
+	}
+	message := telegram.TruncateMessage(entries, telegram.MaxMessageLength)
+
+	if hasBoard && !usr.LiveBoard.HasNewSymbols(symbols) {
+		_, err := telegramClient.EditMessage(chatID, messageID, message, tele.ModeHTML, tele.NoPreview)
+		if err == nil || errors.Is(err, tele.ErrMessageNotModified) || errors.Is(err, tele.ErrSameMessageContent) {
+			usr.LiveBoard.SetMessage(chatID, messageID, symbols)
+			return
+		}
+		log.Printf("Error editing live board message %d: %v, sending a new one\n", messageID, err)
+	}
+
+	recipient := &tele.Chat{ID: chatID}
+	sent, err := telegramClient.SendMessage(recipient, message, threadOptions)
+	if err != nil {
+		log.Printf("Error sending message: %v\n", err)
+		return
+	}
+	if err := telegramClient.PinMessage(chatID, sent.ID); err != nil {
+		log.Printf("Error pinning live board message: %v\n", err)
+	}
+
+	usr.LiveBoard.SetMessage(chatID, sent.ID, symbols)
+	if err := db.SaveLiveBoard(usr.GetEmail(), chatID, sent.ID); err != nil {
+		log.Printf("Error saving live board message: %v\n", err)
+	}
+}
+
 func processLogTicker(trackerInstance *tracker.Tracker) {
 	trackedSymbols := trackerInstance.GetTrackedSymbols()
 	if len(trackedSymbols) == 0 {
@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/client/internal/telegram"
+	"github.com/agopankov/imPulse/client/internal/templates"
+	"github.com/agopankov/imPulse/client/internal/tracker"
+	"github.com/agopankov/imPulse/client/internal/user"
+	"github.com/agopankov/imPulse/client/internal/venue"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	tele "gopkg.in/telebot.v3"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	maxWhaleSymbols     = 200
+	whaleReconnectDelay = 10 * time.Second
+)
+
+// whaleWatcher keeps the server's interest in the tracked coins fresh and one
+// whale trade stream open with the user's current thresholds.
+type whaleWatcher struct {
+	cancel   context.CancelFunc
+	settings user.WhaleSettings
+}
+
+func (w *whaleWatcher) sync(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, trackerInstance *tracker.Tracker) {
+	clientID := strconv.FormatInt(usr.GetFirstChatID(), 10)
+	if !usr.Whales.IsEnabled() {
+		if w.cancel != nil {
+			w.stop()
+			setWhaleInterest(ctx, binanceClient, clientID, nil)
+		}
+		return
+	}
+
+	var symbols []string
+	for symbol := range trackerInstance.GetTrackedSymbols() {
+		if exchange, _ := venue.Split(symbol); exchange == venue.Default {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	if len(symbols) > maxWhaleSymbols {
+		symbols = symbols[:maxWhaleSymbols]
+	}
+	setWhaleInterest(ctx, binanceClient, clientID, symbols)
+
+	settings := usr.Whales.GetSettings()
+	if w.cancel != nil && settings == w.settings {
+		return
+	}
+	w.stop()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+	w.settings = settings
+	go streamWhales(streamCtx, secondTelegramClient, binanceClient, usr, clientID, settings)
+}
+
+func (w *whaleWatcher) stop() {
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+}
+
+func setWhaleInterest(ctx context.Context, binanceClient proto.BinanceServiceClient, clientID string, symbols []string) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := binanceClient.SetInterest(ctx, &proto.InterestRequest{ClientId: clientID, Symbols: symbols}); err != nil {
+		log.Printf("Error setting whale interest for %s: %v", clientID, err)
+	}
+}
+
+func streamWhales(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, clientID string, settings user.WhaleSettings) {
+	for {
+		err := receiveWhales(ctx, secondTelegramClient, binanceClient, usr, clientID, settings)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Whale trade stream for %s failed: %v", clientID, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(whaleReconnectDelay):
+		}
+	}
+}
+
+func receiveWhales(ctx context.Context, secondTelegramClient *telegram.Client, binanceClient proto.BinanceServiceClient, usr *user.User, clientID string, settings user.WhaleSettings) error {
+	stream, err := binanceClient.StreamWhaleTrades(ctx, &proto.WhaleTradesRequest{
+		ClientId:           clientID,
+		MinNotional:        settings.MinNotional,
+		BurstNotional:      settings.BurstNotional,
+		BurstWindowSeconds: int32(settings.BurstWindow.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		trade, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Whale %s %s: %.0f USDT in %d trades", trade.Side, trade.Symbol, trade.Notional, trade.Trades)
+		message := templates.RenderWhaleAlert(usr.GetMessageStyle(), usr.GetLanguage(), templates.WhaleAlert{
+			Symbol:   trade.Symbol,
+			Buy:      trade.Side == "buy",
+			Price:    trade.Price,
+			Quantity: trade.Quantity,
+			Notional: trade.Notional,
+			Trades:   int(trade.Trades),
+		})
+		if usr.QuietHours.IsActive(time.Now()) {
+			usr.QuietHours.Hold(user.HeldWhale, message)
+			continue
+		}
+
+		secondThreadOptions := &tele.SendOptions{ThreadID: usr.GetSecondThreadID(), ParseMode: tele.ModeHTML, DisableWebPagePreview: true}
+		recipient := &tele.Chat{ID: usr.GetSecondChatID()}
+		if _, err := secondTelegramClient.SendLongMessage(recipient, message, secondThreadOptions); err != nil {
+			log.Printf("Error sending whale alert: %v\n", err)
+		}
+	}
+}
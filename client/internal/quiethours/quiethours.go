@@ -0,0 +1,78 @@
+package quiethours
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("quiet hours must look like 23:00-07:00 Europe/Moscow")
+
+type Schedule struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+func Parse(text string) (*Schedule, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, ErrInvalidSchedule
+	}
+
+	startText, endText, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return nil, ErrInvalidSchedule
+	}
+	start, err := parseClock(startText)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(endText)
+	if err != nil {
+		return nil, err
+	}
+	if start == end {
+		return nil, ErrInvalidSchedule
+	}
+
+	location := time.UTC
+	if len(fields) == 2 {
+		location, err = time.LoadLocation(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", fields[1])
+		}
+	}
+
+	return &Schedule{
+		Start:    start,
+		End:      end,
+		Location: location,
+	}, nil
+}
+
+func (s *Schedule) Active(t time.Time) bool {
+	local := t.In(s.Location)
+	now := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if s.Start < s.End {
+		return now >= s.Start && now < s.End
+	}
+	return now >= s.Start || now < s.End
+}
+
+func (s *Schedule) String() string {
+	return fmt.Sprintf("%s-%s %s", formatClock(s.Start), formatClock(s.End), s.Location)
+}
+
+func parseClock(text string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return 0, ErrInvalidSchedule
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
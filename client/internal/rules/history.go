@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"sync"
+	"time"
+)
+
+var ChangeWindows = map[string]time.Duration{
+	VarChange1m:  time.Minute,
+	VarChange5m:  5 * time.Minute,
+	VarChange15m: 15 * time.Minute,
+	VarChange1h:  time.Hour,
+}
+
+type History struct {
+	mu        sync.Mutex
+	window    time.Duration
+	snapshots []priceSnapshot
+}
+
+type priceSnapshot struct {
+	at     time.Time
+	prices map[string]float64
+}
+
+func NewHistory(window time.Duration) *History {
+	return &History{window: window}
+}
+
+func (h *History) Record(at time.Time, prices map[string]float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshots = append(h.snapshots, priceSnapshot{at: at, prices: prices})
+
+	expired := 0
+	for expired < len(h.snapshots)-1 && at.Sub(h.snapshots[expired+1].at) >= h.window {
+		expired++
+	}
+	if expired > 0 {
+		h.snapshots = append([]priceSnapshot(nil), h.snapshots[expired:]...)
+	}
+}
+
+func (h *History) PriceAt(symbol string, at time.Time) (float64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.snapshots) - 1; i >= 0; i-- {
+		if h.snapshots[i].at.After(at) {
+			continue
+		}
+		price, ok := h.snapshots[i].prices[symbol]
+		return price, ok && price > 0
+	}
+	return 0, false
+}
+
+func (h *History) Snapshot(symbol string, price, change24h, quoteVolume float64, now time.Time) Snapshot {
+	snapshot := Snapshot{
+		VarPrice:       price,
+		VarChange24h:   change24h,
+		VarQuoteVolume: quoteVolume,
+	}
+	for name, window := range ChangeWindows {
+		if previous, ok := h.PriceAt(symbol, now.Add(-window)); ok {
+			snapshot[name] = (price/previous - 1) * 100
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,375 @@
+package rules
+
+import (
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	VarPrice       = "price"
+	VarChange24h   = "ch24"
+	VarQuoteVolume = "qvol"
+	VarChange1m    = "ch1m"
+	VarChange5m    = "ch5m"
+	VarChange15m   = "ch15m"
+	VarChange1h    = "ch1h"
+)
+
+var variables = map[string]bool{
+	VarPrice:       true,
+	VarChange24h:   true,
+	VarQuoteVolume: true,
+	VarChange1m:    true,
+	VarChange5m:    true,
+	VarChange15m:   true,
+	VarChange1h:    true,
+}
+
+// Snapshot holds the variables known for a symbol. A variable is missing
+// while there is not enough history for it yet.
+type Snapshot map[string]float64
+
+// Expr is a parsed rule. It matches only when its value is known to be true:
+// a comparison on a missing variable is unknown, and so is anything built on
+// it that the rest of the expression doesn't settle, NOT included.
+type Expr interface {
+	Eval(snapshot Snapshot) bool
+}
+
+type truth int
+
+const (
+	unknown truth = iota
+	isFalse
+	isTrue
+)
+
+func truthOf(value bool) truth {
+	if value {
+		return isTrue
+	}
+	return isFalse
+}
+
+type node interface {
+	eval(snapshot Snapshot) truth
+}
+
+type expr struct{ root node }
+
+func (e expr) Eval(snapshot Snapshot) bool {
+	return e.root.eval(snapshot) == isTrue
+}
+
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+func Variables() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Parse(text string) (Expr, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: next.pos, Msg: fmt.Sprintf("unexpected %q", next.text)}
+	}
+	return expr{root: root}, nil
+}
+
+type andExpr struct{ left, right node }
+
+func (e andExpr) eval(snapshot Snapshot) truth {
+	left, right := e.left.eval(snapshot), e.right.eval(snapshot)
+	switch {
+	case left == isFalse || right == isFalse:
+		return isFalse
+	case left == unknown || right == unknown:
+		return unknown
+	}
+	return isTrue
+}
+
+type orExpr struct{ left, right node }
+
+func (e orExpr) eval(snapshot Snapshot) truth {
+	left, right := e.left.eval(snapshot), e.right.eval(snapshot)
+	switch {
+	case left == isTrue || right == isTrue:
+		return isTrue
+	case left == unknown || right == unknown:
+		return unknown
+	}
+	return isFalse
+}
+
+type notExpr struct{ expr node }
+
+func (e notExpr) eval(snapshot Snapshot) truth {
+	switch e.expr.eval(snapshot) {
+	case isTrue:
+		return isFalse
+	case isFalse:
+		return isTrue
+	}
+	return unknown
+}
+
+type comparison struct {
+	variable string
+	operator string
+	value    float64
+}
+
+func (c comparison) eval(snapshot Snapshot) truth {
+	actual, ok := snapshot[c.variable]
+	if !ok {
+		return unknown
+	}
+
+	switch c.operator {
+	case ">":
+		return truthOf(actual > c.value)
+	case ">=":
+		return truthOf(actual >= c.value)
+	case "<":
+		return truthOf(actual < c.value)
+	case "<=":
+		return truthOf(actual <= c.value)
+	case "=", "==":
+		return truthOf(actual == c.value)
+	case "!=":
+		return truthOf(actual != c.value)
+	}
+	return isFalse
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case strings.ContainsRune("<>=!", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			operator := string(runes[start:i])
+			if operator == "!" {
+				return nil, &SyntaxError{Pos: start, Msg: "expected !="}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: start})
+		case r == '&' || r == '|':
+			start := i
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("expected %c%c", r, r)}
+			}
+			i += 2
+			keyword := "AND"
+			if r == '|' {
+				keyword = "OR"
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: keyword, pos: start})
+		case unicode.IsDigit(r) || r == '.' || r == '-':
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == ',') {
+				i++
+			}
+			if i < len(runes) && strings.ContainsRune("kKmMbB%", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(name string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && strings.EqualFold(t.text, name) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("NOT") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: operand}, nil
+	}
+
+	if p.peek().kind == tokenLeftParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRightParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "expected )"}
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	variable := p.next()
+	if variable.kind != tokenIdent {
+		return nil, &SyntaxError{Pos: variable.pos, Msg: "expected a variable"}
+	}
+	name := strings.ToLower(variable.text)
+	if !variables[name] {
+		return nil, &SyntaxError{Pos: variable.pos, Msg: fmt.Sprintf("unknown variable %q, use one of %s", variable.text, strings.Join(Variables(), ", "))}
+	}
+
+	operator := p.next()
+	if operator.kind != tokenOperator {
+		return nil, &SyntaxError{Pos: operator.pos, Msg: "expected a comparison operator"}
+	}
+
+	number := p.next()
+	if number.kind != tokenNumber {
+		return nil, &SyntaxError{Pos: number.pos, Msg: "expected a number"}
+	}
+	value, err := parseNumber(number.text)
+	if err != nil {
+		return nil, &SyntaxError{Pos: number.pos, Msg: fmt.Sprintf("invalid number %q", number.text)}
+	}
+
+	return comparison{variable: name, operator: operator.text, value: value}, nil
+}
+
+// parseNumber reads a rule threshold the way the bot commands read numbers,
+// so "500,000" is five hundred thousand and "2,5" is two and a half, with an
+// optional K, M or B multiplier and % sign.
+func parseNumber(text string) (float64, error) {
+	text = strings.TrimSuffix(text, "%")
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(text, "k") || strings.HasSuffix(text, "K"):
+		multiplier = 1e3
+	case strings.HasSuffix(text, "m") || strings.HasSuffix(text, "M"):
+		multiplier = 1e6
+	case strings.HasSuffix(text, "b") || strings.HasSuffix(text, "B"):
+		multiplier = 1e9
+	}
+	if multiplier != 1 {
+		text = text[:len(text)-1]
+	}
+
+	value, err := i18n.ParseNumber(text)
+	if err != nil {
+		return 0, err
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvalMissingVariables(t *testing.T) {
+	warmingUp := Snapshot{VarPrice: 2, VarChange24h: 12}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"ch24 > 10", true},
+		{"ch1h > 5", false},
+		{"NOT ch1h > 5", false},
+		{"NOT (ch24 > 10 AND ch1h > 5)", false},
+		{"ch24 > 10 AND ch1h > 5", false},
+		{"ch24 > 10 OR ch1h > 5", true},
+		{"ch24 > 20 AND ch1h > 5", false},
+		{"NOT (ch24 > 20 AND ch1h > 5)", true},
+		{"NOT ch24 > 20 OR ch1h > 5", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			expr, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := expr.Eval(warmingUp); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{"qvol > 500000", 500000},
+		{"qvol > 500,000", 500000},
+		{"qvol > 1,000,000", 1000000},
+		{"qvol > 1,000.5", 1000.5},
+		{"ch5m > 2,5", 2.5},
+		{"ch5m > 2.5%", 2.5},
+		{"ch5m > -1", -1},
+		{"qvol > 500k", 500000},
+		{"qvol > 1.5M", 1500000},
+		{"qvol > 2b", 2000000000},
+		{"qvol > 2,5m", 2500000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			expr, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			variable := strings.Fields(tt.text)[0]
+			if got := expr.Eval(Snapshot{variable: tt.want}); got {
+				t.Errorf("matched %s = %v, want the threshold to be exactly %v", variable, tt.want, tt.want)
+			}
+			if got := expr.Eval(Snapshot{variable: tt.want + math.Abs(tt.want)*1e-9 + 1e-9}); !got {
+				t.Errorf("didn't match just above %v", tt.want)
+			}
+		})
+	}
+
+	for _, text := range []string{"qvol > 1,00,0", "qvol > 1.2.3", "qvol > k", "qvol >", "qvol 5"} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid rule", text)
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		text     string
+		snapshot Snapshot
+		want     bool
+	}{
+		// AND binds tighter than OR: a OR (b AND c).
+		{"ch5m > 1 OR ch1h > 1 AND ch24 > 1", Snapshot{VarChange5m: 2, VarChange1h: 0, VarChange24h: 0}, true},
+		{"(ch5m > 1 OR ch1h > 1) AND ch24 > 1", Snapshot{VarChange5m: 2, VarChange1h: 0, VarChange24h: 0}, false},
+		// NOT binds tighter than AND.
+		{"NOT ch5m > 1 AND ch1h > 1", Snapshot{VarChange5m: 0, VarChange1h: 2}, true},
+		{"NOT (ch5m > 1 AND ch1h > 1)", Snapshot{VarChange5m: 2, VarChange1h: 0}, true},
+		{"ch5m > 1 && ch1h > 1 || ch24 > 1", Snapshot{VarChange5m: 0, VarChange1h: 0, VarChange24h: 2}, true},
+		{"price <= 2 and price >= 2 and price != 3", Snapshot{VarPrice: 2}, true},
+		{"price = 2", Snapshot{VarPrice: 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			expr, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := expr.Eval(tt.snapshot); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ type SecretKeys struct {
 	TelegramBotToken       string `json:"TELEGRAM_BOT_TOKEN"`
 	TelegramBotTokenSecond string `json:"TELEGRAM_BOT_TOKEN_SECOND"`
 	PostmarkToken          string `json:"POSTMARK_TOKEN"`
+	WebhookSecret          string `json:"TELEGRAM_WEBHOOK_SECRET"`
+	WebhookSecretSecond    string `json:"TELEGRAM_WEBHOOK_SECRET_SECOND"`
 }
 
 func LoadSecrets() (*SecretKeys, error) {
@@ -17,8 +19,10 @@ func LoadSecrets() (*SecretKeys, error) {
 	firstBotToken := os.Getenv("TELEGRAM_BOT_TOKEN")
 	secondBotToken := os.Getenv("TELEGRAM_BOT_TOKEN_SECOND")
 	postmarkToken := os.Getenv("POSTMARK_TOKEN")
+	webhookSecret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+	webhookSecretSecond := os.Getenv("TELEGRAM_WEBHOOK_SECRET_SECOND")
 
-	if firstBotToken == "" || secondBotToken == "" {
+	if firstBotToken == "" {
 		secretsFile, err := os.ReadFile("/mnt/secrets-store/prod_binance_secret")
 		if err != nil {
 			return nil, err
@@ -32,6 +36,8 @@ func LoadSecrets() (*SecretKeys, error) {
 			TelegramBotToken:       firstBotToken,
 			TelegramBotTokenSecond: secondBotToken,
 			PostmarkToken:          postmarkToken,
+			WebhookSecret:          webhookSecret,
+			WebhookSecretSecond:    webhookSecretSecond,
 		}
 	}
 	return &secrets, nil
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/agopankov/imPulse/client/internal/database"
+	"github.com/agopankov/imPulse/client/internal/i18n"
 	"github.com/agopankov/imPulse/client/internal/telegram"
 	tele "gopkg.in/telebot.v3"
 )
@@ -14,15 +15,24 @@ func SendServiceRestartNotifications(db database.Database, telegramClient *teleg
 		log.Fatalf("Failed to retrieve users: %v", err)
 	}
 
-	notificationMessage := "⛔️The service has been restarted.\nYou need to resend the /start command in each chatbot."
+	notificationKey := i18n.ServiceRestarted
+	if telegramClient == secondTelegramClient {
+		notificationKey = i18n.ServiceRestartedSingle
+	}
 
 	for _, usr := range usersFromDB {
+		notificationMessage := i18n.T(i18n.FromLanguageCode(usr.Language), notificationKey)
+
 		botChat := &tele.User{ID: usr.FirstBotID}
 		_, err = telegramClient.SendMessage(botChat, notificationMessage)
 		if err != nil {
 			log.Printf("Failed to send notification to user %v on first bot: %v", usr.Email, err)
 		}
 
+		if telegramClient == secondTelegramClient {
+			continue
+		}
+
 		_, err = secondTelegramClient.SendMessage(botChat, notificationMessage)
 		if err != nil {
 			log.Printf("Failed to send notification to user %v on second bot: %v", usr.Email, err)
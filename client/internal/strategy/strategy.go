@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/baseline"
+	"github.com/agopankov/imPulse/client/internal/tracker"
+	"strconv"
+	"time"
+)
+
+type Settings struct {
+	ChangePercent24 float64
+	PumpPercent     float64
+	WaitTime        time.Duration
+	Cooldown        time.Duration
+	Steps           []float64
+	Retrace         float64
+	Baseline        baseline.Settings
+}
+
+func NewSymbol(symbol string, price, change24h float64, now time.Time) tracker.SymbolChange {
+	return tracker.SymbolChange{
+		Symbol:           symbol,
+		PriceChange:      fmt.Sprintf("%.8f", price),
+		FirstPriceChange: fmt.Sprintf("%.8f", price),
+		PriceChangePct:   change24h,
+		AddedAt:          now,
+	}
+}
+
+func ShouldTrack(settings Settings, change24h float64) bool {
+	return change24h >= settings.ChangePercent24
+}
+
+func ShouldUntrack(settings Settings, change24h float64) bool {
+	return change24h <= settings.ChangePercent24
+}
+
+func NextPumpLevel(steps []float64, lastPumpPct float64) (float64, bool) {
+	switch len(steps) {
+	case 0:
+		return 0, false
+	case 1:
+		// A step of zero would alert on every tick, it means no step.
+		if steps[0] <= 0 {
+			return 0, false
+		}
+		return lastPumpPct + steps[0], true
+	}
+	for _, step := range steps {
+		if step > lastPumpPct {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func Baseline(settings Settings, symbolChange tracker.SymbolChange, history []baseline.PricePoint, now time.Time) float64 {
+	firstPrice, _ := strconv.ParseFloat(symbolChange.FirstPriceChange, 64)
+
+	var price float64
+	var ok bool
+	switch settings.Baseline.Mode {
+	case baseline.ModeRollingMin:
+		price, ok = baseline.RollingMin(history, now, settings.Baseline.Window)
+	case baseline.ModeLookback:
+		price, ok = baseline.Lookback(history, now, settings.Baseline.Window)
+	case baseline.ModeVWAP:
+		price, ok = symbolChange.VWAP, symbolChange.VWAP > 0
+	}
+
+	if !ok {
+		return firstPrice
+	}
+	return price
+}
+
+func PumpPercent(baselinePrice, price float64) float64 {
+	return ((price / baselinePrice) - 1) * 100
+}
+
+func ShouldAlertPump(settings Settings, symbolChange tracker.SymbolChange, pumpPct float64, now time.Time) bool {
+	if !symbolChange.LastPumpAt.IsZero() && now.Sub(symbolChange.LastPumpAt) < settings.Cooldown {
+		return false
+	}
+
+	if !symbolChange.NotificationOfPump {
+		if settings.Baseline.Mode == baseline.ModeFirstPrice && now.Sub(symbolChange.AddedAt) > settings.WaitTime {
+			return false
+		}
+		return pumpPct >= settings.PumpPercent
+	}
+
+	nextLevel, ok := NextPumpLevel(settings.Steps, symbolChange.LastPumpPct)
+	return ok && pumpPct >= nextLevel
+}
+
+func RearmOnRetrace(settings Settings, symbolChange *tracker.SymbolChange, price float64, now time.Time) bool {
+	if !symbolChange.NotificationOfPump || settings.Retrace <= 0 || symbolChange.PeakPrice == 0 || price > symbolChange.PeakPrice*(1-settings.Retrace/100) {
+		return false
+	}
+
+	symbolChange.NotificationOfPump = false
+	symbolChange.AddedAt = now
+	symbolChange.FirstPriceChange = fmt.Sprintf("%.8f", price)
+	symbolChange.LastPumpPct = 0
+	symbolChange.PeakPrice = 0
+	return true
+}
+
+func MarkPumpAlerted(symbolChange *tracker.SymbolChange, pumpPct, price float64, now time.Time) {
+	symbolChange.NotificationOfPump = true
+	symbolChange.LastPumpAt = now
+	symbolChange.LastPumpPct = pumpPct
+	if price > symbolChange.PeakPrice {
+		symbolChange.PeakPrice = price
+	}
+}
+
+func TrackPeak(symbolChange *tracker.SymbolChange, price float64) bool {
+	if !symbolChange.NotificationOfPump || price <= symbolChange.PeakPrice {
+		return false
+	}
+	symbolChange.PeakPrice = price
+	return true
+}
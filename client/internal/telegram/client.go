@@ -1,20 +1,85 @@
 package telegram
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf16"
 
 	tele "gopkg.in/telebot.v3"
 )
 
+const (
+	MaxMessageLength = 4096
+	MaxCaptionLength = 1024
+)
+
+var (
+	ErrNotChatAdmin  = errors.New("only chat administrators can use this chat as an alert destination")
+	ErrBotCannotPost = errors.New("the bot is not allowed to post messages in this chat")
+)
+
 type Client struct {
 	botToken string
 	bot      *tele.Bot
+	queue    *sendQueue
+}
+
+type WebhookConfig struct {
+	PublicURL   string
+	Listen      string
+	SecretToken string
+	TLSCert     string
+	TLSKey      string
 }
 
 func NewClient(botToken string) (*Client, error) {
+	return NewClientWithAPI(botToken, "")
+}
+
+// NewClientWithAPI long-polls a Bot API server other than api.telegram.org,
+// such as a local telegram-bot-api or faketelegram.
+func NewClientWithAPI(botToken string, apiURL string) (*Client, error) {
+	client, err := newClient(botToken, apiURL, &tele.LongPoller{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.bot.RemoveWebhook(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func NewWebhookClient(botToken string, config WebhookConfig) (*Client, error) {
+	if config.PublicURL == "" {
+		return nil, errors.New("webhook public URL is required")
+	}
+
+	webhook := &tele.Webhook{
+		Listen:      config.Listen,
+		SecretToken: config.SecretToken,
+		Endpoint:    &tele.WebhookEndpoint{PublicURL: config.PublicURL},
+	}
+	if config.TLSCert != "" && config.TLSKey != "" {
+		webhook.TLS = &tele.WebhookTLS{
+			Cert: config.TLSCert,
+			Key:  config.TLSKey,
+		}
+	}
+
+	return newClient(botToken, "", webhook)
+}
+
+func newClient(botToken string, apiURL string, poller tele.Poller) (*Client, error) {
 	bot, err := tele.NewBot(tele.Settings{
+		URL:    apiURL,
 		Token:  botToken,
-		Poller: &tele.LongPoller{Timeout: 10 * time.Second},
+		Poller: poller,
 	})
 	if err != nil {
 		return nil, err
@@ -23,6 +88,7 @@ func NewClient(botToken string) (*Client, error) {
 	return &Client{
 		botToken: botToken,
 		bot:      bot,
+		queue:    newSendQueue(),
 	}, nil
 }
 
@@ -30,6 +96,10 @@ func (c *Client) Start() {
 	c.bot.Start()
 }
 
+func (c *Client) Stop() {
+	c.bot.Stop()
+}
+
 func (c *Client) Bot() *tele.Bot {
 	return c.bot
 }
@@ -41,8 +111,69 @@ func (c *Client) HandleText(handler func(m *tele.Message)) {
 	})
 }
 
-func (c *Client) SendMessage(recipient *tele.User, text string) (*tele.Message, error) {
-	return c.bot.Send(recipient, text)
+func (c *Client) SendMessage(recipient tele.Recipient, text string, opts ...interface{}) (*tele.Message, error) {
+	return c.send(PriorityNormal, recipient, text, opts...)
+}
+
+func (c *Client) SendLongMessage(recipient tele.Recipient, text string, opts ...interface{}) ([]*tele.Message, error) {
+	var messages []*tele.Message
+	for _, part := range SplitMessage(text, MaxMessageLength) {
+		message, err := c.SendMessage(recipient, part, opts...)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (c *Client) SendAlert(recipient tele.Recipient, text string, opts ...interface{}) (*tele.Message, error) {
+	return c.send(PriorityHigh, recipient, text, opts...)
+}
+
+// SendPhotoAlert sends the caption along with the photo when it fits
+// Telegram's caption limit, otherwise the photo goes alone and the caption
+// follows as a text alert.
+func (c *Client) SendPhotoAlert(recipient tele.Recipient, photo []byte, caption string, opts ...interface{}) (*tele.Message, error) {
+	long := messageLength(caption) > MaxCaptionLength
+	message, err := c.queue.Do(PriorityHigh, recipient.Recipient(), func() (*tele.Message, error) {
+		file := &tele.Photo{File: tele.FromReader(bytes.NewReader(photo))}
+		if !long {
+			file.Caption = caption
+		}
+		return c.bot.Send(recipient, file, opts...)
+	})
+	if err != nil || !long {
+		return message, err
+	}
+
+	for _, part := range SplitMessage(caption, MaxMessageLength) {
+		if _, err := c.SendAlert(recipient, part, opts...); err != nil {
+			return message, err
+		}
+	}
+	return message, nil
+}
+
+func (c *Client) EditMessage(chatID int64, messageID int, text string, opts ...interface{}) (*tele.Message, error) {
+	stored := tele.StoredMessage{MessageID: strconv.Itoa(messageID), ChatID: chatID}
+	return c.queue.Do(PriorityNormal, strconv.FormatInt(chatID, 10), func() (*tele.Message, error) {
+		return c.bot.Edit(stored, text, opts...)
+	})
+}
+
+func (c *Client) PinMessage(chatID int64, messageID int) error {
+	stored := tele.StoredMessage{MessageID: strconv.Itoa(messageID), ChatID: chatID}
+	_, err := c.queue.Do(PriorityNormal, strconv.FormatInt(chatID, 10), func() (*tele.Message, error) {
+		return nil, c.bot.Pin(stored, tele.Silent)
+	})
+	return err
+}
+
+func (c *Client) send(priority Priority, recipient tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	return c.queue.Do(priority, recipient.Recipient(), func() (*tele.Message, error) {
+		return c.bot.Send(recipient, what, opts...)
+	})
 }
 
 func (c *Client) HandleCommand(command string, handler func(m *tele.Message)) {
@@ -58,3 +189,213 @@ func (c *Client) HandleOnMessage(fn func(m *tele.Message)) {
 		return nil
 	})
 }
+
+func (c *Client) ChatByUsername(name string) (*tele.Chat, error) {
+	return c.bot.ChatByUsername(name)
+}
+
+func (c *Client) VerifyDeliveryTarget(chat *tele.Chat, userID int64) error {
+	if chat.Type == tele.ChatPrivate {
+		if chat.ID != userID {
+			return ErrNotChatAdmin
+		}
+		return nil
+	}
+
+	member, err := c.bot.ChatMemberOf(chat, &tele.User{ID: userID})
+	if err != nil {
+		return err
+	}
+	if member.Role != tele.Creator && member.Role != tele.Administrator {
+		return ErrNotChatAdmin
+	}
+
+	self, err := c.bot.ChatMemberOf(chat, c.bot.Me)
+	if err != nil {
+		return err
+	}
+
+	switch self.Role {
+	case tele.Creator:
+		return nil
+	case tele.Administrator:
+		if chat.Type == tele.ChatChannel && !self.CanPostMessages {
+			return ErrBotCannotPost
+		}
+		return nil
+	case tele.Member:
+		if chat.Type == tele.ChatChannel {
+			return ErrBotCannotPost
+		}
+		return nil
+	case tele.Restricted:
+		if !self.CanSendMessages {
+			return ErrBotCannotPost
+		}
+		return nil
+	default:
+		return ErrBotCannotPost
+	}
+}
+
+func SplitMessage(text string, limit int) []string {
+	var parts []string
+	var builder strings.Builder
+	length := 0
+
+	flush := func() {
+		if builder.Len() > 0 {
+			parts = append(parts, builder.String())
+			builder.Reset()
+			length = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineLength := messageLength(line)
+		if length+lineLength > limit {
+			flush()
+		}
+		for lineLength > limit {
+			head, tail := cutMessage(line, limit)
+			parts = append(parts, head)
+			line = tail
+			lineLength = messageLength(line)
+		}
+		builder.WriteString(line)
+		length += lineLength
+	}
+	flush()
+
+	return parts
+}
+
+// TruncateMessage joins the entries into one message of at most limit. The
+// entries that don't fit are left out whole, verbose ones span several lines,
+// and counted in an "… and N more" line.
+func TruncateMessage(entries []string, limit int) string {
+	text := strings.Join(entries, "")
+	if messageLength(text) <= limit {
+		return text
+	}
+
+	var builder strings.Builder
+	length := 0
+	for i, entry := range entries {
+		more := fmt.Sprintf("… and %d more\n", len(entries)-i)
+		if length+messageLength(entry)+messageLength(more) > limit {
+			builder.WriteString(more)
+			break
+		}
+		builder.WriteString(entry)
+		length += messageLength(entry)
+	}
+	return builder.String()
+}
+
+func messageLength(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+// cutMessage splits a line longer than limit, after the last space that
+// fits when there is one. It never cuts inside an HTML tag or entity, and
+// tags open at the cut are closed in the head and reopened in the tail, so
+// both parts still parse as HTML.
+func cutMessage(text string, limit int) (string, string) {
+	var open, cutOpen, spaceOpen []string
+	cut, spaceCut := 0, 0
+	length, tagStart := 0, -1
+	inEntity := false
+	for i, r := range text {
+		// A cut needs to leave a tail shorter than text once the open
+		// tags are repeated in it.
+		if tagStart < 0 && !inEntity && length > openingLength(open) {
+			if length+closingLength(open) > limit {
+				break
+			}
+			cut, cutOpen = i, open
+			if text[i-1] == ' ' {
+				spaceCut, spaceOpen = i, open
+			}
+		}
+
+		length += len(utf16.Encode([]rune{r}))
+		switch {
+		case r == '<' && !inEntity:
+			tagStart = i
+		case r == '>' && tagStart >= 0:
+			open = updateOpenTags(open, text[tagStart:i+1])
+			tagStart = -1
+		case r == '&' && tagStart < 0:
+			inEntity = true
+		case r == ';' && inEntity:
+			inEntity = false
+		}
+	}
+	if length+closingLength(open) <= limit {
+		return text, ""
+	}
+
+	if spaceCut > 0 {
+		cut, cutOpen = spaceCut, spaceOpen
+	}
+	if cut == 0 {
+		// Not even one tag or entity fits, cut it like plain text.
+		for i := range text {
+			if i > 0 {
+				return text[:i], text[i:]
+			}
+		}
+	}
+
+	var head, tail strings.Builder
+	head.WriteString(text[:cut])
+	for i := len(cutOpen) - 1; i >= 0; i-- {
+		head.WriteString("</" + tagName(cutOpen[i]) + ">")
+	}
+	for _, tag := range cutOpen {
+		tail.WriteString(tag)
+	}
+	tail.WriteString(text[cut:])
+	return head.String(), tail.String()
+}
+
+// updateOpenTags returns the tags still open after tag, without changing
+// open.
+func updateOpenTags(open []string, tag string) []string {
+	switch {
+	case strings.HasPrefix(tag, "</"):
+		if len(open) > 0 {
+			return open[:len(open)-1]
+		}
+		return open
+	case strings.HasSuffix(tag, "/>"):
+		return open
+	default:
+		return append(open[:len(open):len(open)], tag)
+	}
+}
+
+func openingLength(open []string) int {
+	length := 0
+	for _, tag := range open {
+		length += messageLength(tag)
+	}
+	return length
+}
+
+func closingLength(open []string) int {
+	length := 0
+	for _, tag := range open {
+		length += len("</" + tagName(tag) + ">")
+	}
+	return length
+}
+
+func tagName(tag string) string {
+	name := strings.TrimPrefix(strings.TrimSuffix(tag, ">"), "<")
+	if i := strings.IndexByte(name, ' '); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
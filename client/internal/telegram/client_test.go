@@ -0,0 +1,70 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCutMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		head  string
+		tail  string
+	}{
+		{"after a space", "pump alert on PEPEUSDT", 12, "pump alert ", "on PEPEUSDT"},
+		{"no space", "abcdefghij", 4, "abcd", "efghij"},
+		{"not inside a tag", `ab <a href="https://x">link</a>`, 8, "ab ", `<a href="https://x">link</a>`},
+		{"not inside an entity", "a &amp; b&lt;c", 11, "a &amp; ", "b&lt;c"},
+		{"reopens tags", "<b>bold text here</b>", 18, "<b>bold text </b>", "<b>here</b>"},
+		{"fits", "<b>ok</b>", 9, "<b>ok</b>", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail := cutMessage(tt.text, tt.limit)
+			if head != tt.head || tail != tt.tail {
+				t.Errorf("cutMessage(%q, %d) = %q, %q, want %q, %q", tt.text, tt.limit, head, tail, tt.head, tt.tail)
+			}
+		})
+	}
+}
+
+func TestSplitMessageKeepsTagsBalanced(t *testing.T) {
+	line := strings.Repeat(`<a href="https://www.binance.com/en/trade/PEPE_USDT">PEPE</a> <code>0.0000012</code> &amp; `, 30)
+	parts := SplitMessage("header\n"+line+"\n", 200)
+	if len(parts) < 2 {
+		t.Fatalf("got %d parts, want the line split", len(parts))
+	}
+	for i, part := range parts {
+		if messageLength(part) > 200 {
+			t.Errorf("part %d is %d long", i, messageLength(part))
+		}
+		if strings.Count(part, "<a ") != strings.Count(part, "</a>") || strings.Count(part, "<code>") != strings.Count(part, "</code>") {
+			t.Errorf("part %d has unbalanced tags: %q", i, part)
+		}
+		if strings.Count(part, "<") != strings.Count(part, ">") || strings.Count(part, "&") != strings.Count(part, ";") {
+			t.Errorf("part %d cuts a tag or entity: %q", i, part)
+		}
+	}
+	if got := strings.Join(parts, ""); strings.Count(got, "PEPE</a>") != 30 {
+		t.Errorf("lost text while splitting: %q", got)
+	}
+}
+
+func TestTruncateMessageCountsEntries(t *testing.T) {
+	entries := []string{
+		"🚀 PEPEUSDT\nPrice: 0.0000012\n24h: +18.5%\n\n",
+		"🚀 WIFUSDT\nPrice: 2.41\n24h: +12.1%\n\n",
+		"🚀 BONKUSDT\nPrice: 0.000021\n24h: +10.3%\n\n",
+	}
+	limit := messageLength(entries[0]) + messageLength("… and 2 more\n")
+
+	got := TruncateMessage(entries, limit)
+	if want := entries[0] + "… and 2 more\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := TruncateMessage(entries, 1000); got != strings.Join(entries, "") {
+		t.Errorf("truncated a message that fits: %q", got)
+	}
+}
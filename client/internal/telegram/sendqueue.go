@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+const (
+	globalSendInterval      = time.Second / 30
+	privateChatSendInterval = time.Second
+	groupChatSendInterval   = 3 * time.Second
+	maxSendAttempts         = 5
+)
+
+type sendResult struct {
+	message *tele.Message
+	err     error
+}
+
+type sendJob struct {
+	chat     string
+	do       func() (*tele.Message, error)
+	priority Priority
+	attempts int
+	result   chan sendResult
+}
+
+type sendQueue struct {
+	mu             sync.Mutex
+	high           []*sendJob
+	normal         []*sendJob
+	nextChatSend   map[string]time.Time
+	nextGlobalSend time.Time
+	wake           chan struct{}
+}
+
+func newSendQueue() *sendQueue {
+	q := &sendQueue{
+		nextChatSend: make(map[string]time.Time),
+		wake:         make(chan struct{}, 1),
+	}
+	go q.run()
+	return q
+}
+
+func (q *sendQueue) Do(priority Priority, chat string, do func() (*tele.Message, error)) (*tele.Message, error) {
+	job := &sendJob{
+		chat:     chat,
+		do:       do,
+		priority: priority,
+		result:   make(chan sendResult, 1),
+	}
+
+	q.mu.Lock()
+	if priority == PriorityHigh {
+		q.high = append(q.high, job)
+	} else {
+		q.normal = append(q.normal, job)
+	}
+	q.mu.Unlock()
+	q.notify()
+
+	result := <-job.result
+	return result.message, result.err
+}
+
+func (q *sendQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *sendQueue) run() {
+	for {
+		job, wait := q.next()
+		if job == nil {
+			if wait < 0 {
+				<-q.wake
+				continue
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-q.wake:
+				timer.Stop()
+			case <-timer.C:
+			}
+			continue
+		}
+
+		message, err := job.do()
+
+		var floodErr tele.FloodError
+		if errors.As(err, &floodErr) && job.attempts < maxSendAttempts {
+			retryAfter := time.Duration(floodErr.RetryAfter) * time.Second
+			log.Printf("Telegram flood limit for chat %s, retrying in %s", job.chat, retryAfter)
+			q.retry(job, retryAfter)
+			continue
+		}
+
+		job.result <- sendResult{message: message, err: err}
+	}
+}
+
+func (q *sendQueue) next() (*sendJob, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	if len(q.high) == 0 && len(q.normal) == 0 {
+		for chat, next := range q.nextChatSend {
+			if now.After(next) {
+				delete(q.nextChatSend, chat)
+			}
+		}
+		return nil, -1
+	}
+
+	if now.Before(q.nextGlobalSend) {
+		return nil, q.nextGlobalSend.Sub(now)
+	}
+
+	wait := time.Duration(-1)
+	for _, queue := range []*[]*sendJob{&q.high, &q.normal} {
+		for i, job := range *queue {
+			if next, ok := q.nextChatSend[job.chat]; ok && now.Before(next) {
+				if wait < 0 || next.Sub(now) < wait {
+					wait = next.Sub(now)
+				}
+				continue
+			}
+
+			*queue = append((*queue)[:i], (*queue)[i+1:]...)
+			q.nextGlobalSend = now.Add(globalSendInterval)
+			q.nextChatSend[job.chat] = now.Add(chatSendInterval(job.chat))
+			return job, 0
+		}
+	}
+
+	return nil, wait
+}
+
+// retry puts the job back in front of its queue. A flood error means the bot
+// as a whole sends too fast, so every chat waits, not just this one.
+func (q *sendQueue) retry(job *sendJob, retryAfter time.Duration) {
+	q.mu.Lock()
+	job.attempts++
+	resume := time.Now().Add(retryAfter)
+	q.nextChatSend[job.chat] = resume
+	if resume.After(q.nextGlobalSend) {
+		q.nextGlobalSend = resume
+	}
+	if job.priority == PriorityHigh {
+		q.high = append([]*sendJob{job}, q.high...)
+	} else {
+		q.normal = append([]*sendJob{job}, q.normal...)
+	}
+	q.mu.Unlock()
+}
+
+// chatSendInterval is the private chat interval for positive chat IDs only.
+// Groups have negative IDs and "@name" recipients are always channels or
+// groups, bots can't address users by name.
+func chatSendInterval(chat string) time.Duration {
+	id, err := strconv.ParseInt(chat, 10, 64)
+	if err != nil || id < 0 {
+		return groupChatSendInterval
+	}
+	return privateChatSendInterval
+}
@@ -0,0 +1,135 @@
+package telegram
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// idleQueue is a queue without its run loop, next hands out the jobs.
+func idleQueue() *sendQueue {
+	return &sendQueue{nextChatSend: make(map[string]time.Time), wake: make(chan struct{}, 1)}
+}
+
+func enqueue(q *sendQueue, priority Priority, chat string) *sendJob {
+	job := &sendJob{chat: chat, priority: priority, result: make(chan sendResult, 1)}
+	if priority == PriorityHigh {
+		q.high = append(q.high, job)
+	} else {
+		q.normal = append(q.normal, job)
+	}
+	return job
+}
+
+// nextNow clears the global interval, so next only paces by chat.
+func nextNow(q *sendQueue) (*sendJob, time.Duration) {
+	q.nextGlobalSend = time.Time{}
+	return q.next()
+}
+
+func TestChatSendInterval(t *testing.T) {
+	tests := []struct {
+		chat string
+		want time.Duration
+	}{
+		{"123456789", privateChatSendInterval},
+		{"-100123456789", groupChatSendInterval},
+		{"-123456", groupChatSendInterval},
+		{"@impulse_alerts", groupChatSendInterval},
+	}
+	for _, tt := range tests {
+		if got := chatSendInterval(tt.chat); got != tt.want {
+			t.Errorf("chatSendInterval(%q) = %v, want %v", tt.chat, got, tt.want)
+		}
+	}
+}
+
+func TestSendQueuePriority(t *testing.T) {
+	q := idleQueue()
+	normal := enqueue(q, PriorityNormal, "1")
+	high := enqueue(q, PriorityHigh, "2")
+
+	if job, _ := nextNow(q); job != high {
+		t.Fatal("normal message sent before a high priority one")
+	}
+	if job, _ := nextNow(q); job != normal {
+		t.Fatal("normal message not sent after the high priority one")
+	}
+	if job, wait := nextNow(q); job != nil || wait >= 0 {
+		t.Errorf("empty queue returned %v, %v", job, wait)
+	}
+}
+
+func TestSendQueuePacing(t *testing.T) {
+	q := idleQueue()
+	first := enqueue(q, PriorityNormal, "1")
+	second := enqueue(q, PriorityNormal, "1")
+	other := enqueue(q, PriorityNormal, "-100")
+
+	if job, _ := q.next(); job != first {
+		t.Fatal("first message not sent")
+	}
+	if job, wait := q.next(); job != nil || wait <= 0 || wait > globalSendInterval {
+		t.Fatalf("got %v, %v right after a send, want to wait for the global interval", job, wait)
+	}
+
+	// The chat just sent to waits, the queue moves on to the next chat.
+	if job, _ := nextNow(q); job != other {
+		t.Fatal("message for another chat held back")
+	}
+	if wait := q.nextChatSend["-100"].Sub(time.Now()); wait <= privateChatSendInterval || wait > groupChatSendInterval {
+		t.Errorf("group chat paced by %v, want %v", wait, groupChatSendInterval)
+	}
+	if job, wait := nextNow(q); job != nil || wait <= 0 || wait > privateChatSendInterval {
+		t.Fatalf("got %v, %v, want to wait for the private chat interval", job, wait)
+	}
+
+	q.nextChatSend["1"] = time.Now().Add(-time.Millisecond)
+	if job, _ := nextNow(q); job != second {
+		t.Fatal("second message not sent once the chat interval passed")
+	}
+}
+
+func TestSendQueueFloodSlowsEveryChat(t *testing.T) {
+	q := idleQueue()
+	flooded := enqueue(q, PriorityNormal, "1")
+	job, _ := q.next()
+	q.retry(job, time.Minute)
+	enqueue(q, PriorityHigh, "2")
+
+	job, wait := q.next()
+	if job != nil || wait < 59*time.Second {
+		t.Fatalf("got %v, %v after a flood error, want every chat to wait a minute", job, wait)
+	}
+	if len(q.normal) != 1 || q.normal[0] != flooded || flooded.attempts != 1 {
+		t.Errorf("flooded message not queued again: %v", q.normal)
+	}
+}
+
+func TestSendQueueRetriesFloodErrors(t *testing.T) {
+	q := newSendQueue()
+
+	calls := 0
+	message, err := q.Do(PriorityNormal, "1", func() (*tele.Message, error) {
+		calls++
+		if calls == 1 {
+			return nil, tele.FloodError{RetryAfter: 0}
+		}
+		return &tele.Message{ID: 42}, nil
+	})
+	if err != nil || message == nil || message.ID != 42 || calls != 2 {
+		t.Errorf("got %v, %v after %d calls, want the retried message", message, err != nil, calls)
+	}
+
+	calls = 0
+	_, err = q.Do(PriorityNormal, "2", func() (*tele.Message, error) {
+		calls++
+		return nil, tele.FloodError{RetryAfter: 0}
+	})
+	var floodErr tele.FloodError
+	if !errors.As(err, &floodErr) || calls != maxSendAttempts+1 {
+		t.Errorf("gave up after %d calls with a flood error %v, want %d", calls, errors.As(err, &floodErr), maxSendAttempts+1)
+	}
+}
@@ -0,0 +1,462 @@
+package templates
+
+import (
+	"fmt"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/client/internal/venue"
+	"html"
+	"strings"
+	"time"
+)
+
+type Style int
+
+const (
+	StyleCompact Style = iota
+	StyleVerbose
+)
+
+type Trend int
+
+const (
+	TrendFlat Trend = iota
+	TrendUp
+	TrendDown
+	TrendNew
+)
+
+type SymbolLine struct {
+	Symbol     string
+	Price      float64
+	Change24h  float64
+	AlertPrice float64
+	Trend      Trend
+}
+
+type PumpAlert struct {
+	Symbol        string
+	Price         float64
+	Change24h     float64
+	BaselinePrice float64
+	Liquidity     *Liquidity
+}
+
+// Liquidity is the order book within ±Band% of the price, nil when unknown.
+type Liquidity struct {
+	Band      float64
+	BidDepth  float64
+	AskDepth  float64
+	Imbalance float64
+}
+
+type IndicatorAlert struct {
+	Symbol    string
+	Price     float64
+	Condition string
+}
+
+type RuleAlert struct {
+	Symbol    string
+	Price     float64
+	Change24h float64
+	RuleID    int
+	Rule      string
+}
+
+type OpenInterestAlert struct {
+	Symbol       string
+	Price        float64
+	Change       float64
+	Window       time.Duration
+	From         float64
+	To           float64
+	OpenInterest float64
+	Change24h    float64
+}
+
+type WhaleAlert struct {
+	Symbol   string
+	Buy      bool
+	Price    float64
+	Quantity float64
+	Notional float64
+	Trades   int
+}
+
+// ListingAlert kinds are the server's: new, trading and delisting. Plain
+// status changes are not alerted.
+type ListingAlert struct {
+	Symbol         string
+	Kind           string
+	Status         string
+	PreviousStatus string
+}
+
+type FundingAlert struct {
+	Symbol      string
+	MarkPrice   float64
+	FundingRate float64
+	NextFunding time.Duration
+	SettledRate float64
+	Settled     bool
+}
+
+func ParseStyle(name string) (Style, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "compact":
+		return StyleCompact, true
+	case "verbose":
+		return StyleVerbose, true
+	}
+	return StyleCompact, false
+}
+
+func (s Style) String() string {
+	if s == StyleVerbose {
+		return "verbose"
+	}
+	return "compact"
+}
+
+func RenderSymbolLine(style Style, lang i18n.Language, line SymbolLine) string {
+	base := baseAsset(line.Symbol)
+	sinceAlert := ""
+	if line.AlertPrice > 0 && line.Trend != TrendNew {
+		sinceAlert = i18n.FormatPercent(lang, PercentChange(line.AlertPrice, line.Price), true)
+	}
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("%s <b>%s / USDT</b>\n", trendEmoji(line.Trend), escape(base)))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, line.Price)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelChange24h), i18n.FormatPercent(lang, line.Change24h, false)))
+		if sinceAlert != "" {
+			builder.WriteString(fmt.Sprintf("%s: %s (%s <code>%s</code>)\n", i18n.T(lang, i18n.LabelSinceAlert), sinceAlert, i18n.T(lang, i18n.LabelFrom), i18n.FormatPrice(lang, line.AlertPrice)))
+		}
+		builder.WriteString(links(line.Symbol) + "\n\n")
+		return builder.String()
+	}
+
+	message := fmt.Sprintf("%s %s / USDT %s %s", trendEmoji(line.Trend), symbolLink(line.Symbol), compactPrice(lang, line.Price), compactChange24h(lang, line.Change24h))
+	if sinceAlert != "" {
+		message += fmt.Sprintf(" (%s)", sinceAlert)
+	}
+	return message + fmt.Sprintf(" <a href=\"%s\">📊</a>\n", TradingViewURL(line.Symbol))
+}
+
+func RenderPumpAlert(style Style, lang i18n.Language, alert PumpAlert) string {
+	base := baseAsset(alert.Symbol)
+	pump := i18n.FormatPercent(lang, PercentChange(alert.BaselinePrice, alert.Price), true)
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("🚀 <b>%s / USDT</b>\n", escape(base)))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.Price)))
+		builder.WriteString(fmt.Sprintf("%s: %s %s <code>%s</code>\n", i18n.T(lang, i18n.LabelPump), pump, i18n.T(lang, i18n.LabelFrom), i18n.FormatPrice(lang, alert.BaselinePrice)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelChange24h), i18n.FormatPercent(lang, alert.Change24h, false)))
+		if book := alert.Liquidity; book != nil {
+			builder.WriteString(fmt.Sprintf("%s ±%s: %s <code>%s</code> / %s <code>%s</code> USDT, %s %s\n",
+				i18n.T(lang, i18n.LabelBook),
+				i18n.FormatPercent(lang, book.Band, false),
+				i18n.T(lang, i18n.LabelBids),
+				i18n.FormatNumber(lang, book.BidDepth, 0),
+				i18n.T(lang, i18n.LabelAsks),
+				i18n.FormatNumber(lang, book.AskDepth, 0),
+				i18n.T(lang, i18n.LabelImbalance),
+				i18n.FormatPercent(lang, book.Imbalance*100, true),
+			))
+		}
+		builder.WriteString(links(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	book := ""
+	if alert.Liquidity != nil {
+		book = fmt.Sprintf(" OB±%s: <code>%s/%s</code> (%s)",
+			i18n.FormatPercent(lang, alert.Liquidity.Band, false),
+			i18n.FormatNumber(lang, alert.Liquidity.BidDepth, 0),
+			i18n.FormatNumber(lang, alert.Liquidity.AskDepth, 0),
+			i18n.FormatPercent(lang, alert.Liquidity.Imbalance*100, true),
+		)
+	}
+	return fmt.Sprintf("🚀 %s / USDT %s %s (PrP: <code>%s</code>, %s)%s <a href=\"%s\">📊</a>\n",
+		symbolLink(alert.Symbol),
+		compactPrice(lang, alert.Price),
+		compactChange24h(lang, alert.Change24h),
+		i18n.FormatPrice(lang, alert.BaselinePrice),
+		pump,
+		book,
+		TradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderIndicatorAlert(style Style, lang i18n.Language, alert IndicatorAlert) string {
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("📐 <b>%s / USDT</b>\n", escape(baseAsset(alert.Symbol))))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.Price)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelSignal), escape(alert.Condition)))
+		builder.WriteString(links(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("📐 %s / USDT %s %s <a href=\"%s\">📊</a>\n",
+		symbolLink(alert.Symbol),
+		compactPrice(lang, alert.Price),
+		escape(alert.Condition),
+		TradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderRuleAlert(style Style, lang i18n.Language, alert RuleAlert) string {
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("⚡️ <b>%s / USDT</b>\n", escape(baseAsset(alert.Symbol))))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.Price)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelChange24h), i18n.FormatPercent(lang, alert.Change24h, false)))
+		builder.WriteString(fmt.Sprintf("%s #%d: <code>%s</code>\n", i18n.T(lang, i18n.LabelRule), alert.RuleID, escape(alert.Rule)))
+		builder.WriteString(links(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("⚡️ %s / USDT %s %s (#%d <code>%s</code>) <a href=\"%s\">📊</a>\n",
+		symbolLink(alert.Symbol),
+		compactPrice(lang, alert.Price),
+		compactChange24h(lang, alert.Change24h),
+		alert.RuleID,
+		escape(alert.Rule),
+		TradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderOpenInterestAlert(style Style, lang i18n.Language, alert OpenInterestAlert) string {
+	change := i18n.FormatPercent(lang, alert.Change, true)
+	window := i18n.FormatNumber(lang, alert.Window.Minutes(), 0)
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("🧲 <b>%s / USDT PERP</b>\n", escape(baseAsset(alert.Symbol))))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.Price)))
+		builder.WriteString(fmt.Sprintf("%s: %s / %s min (<code>%s</code> USDT)\n", i18n.T(lang, i18n.LabelOpenInterest), change, window, i18n.FormatNumber(lang, alert.OpenInterest, 0)))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code> → <code>%s</code>\n", i18n.T(lang, i18n.LabelContracts), i18n.FormatNumber(lang, alert.From, 0), i18n.FormatNumber(lang, alert.To, 0)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelChange24h), i18n.FormatPercent(lang, alert.Change24h, false)))
+		builder.WriteString(futuresLinks(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("🧲 %s / USDT PERP %s OI: %s / %s min %s <a href=\"%s\">📊</a>\n",
+		futuresSymbolLink(alert.Symbol),
+		compactPrice(lang, alert.Price),
+		change,
+		window,
+		compactChange24h(lang, alert.Change24h),
+		FuturesTradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderWhaleAlert(style Style, lang i18n.Language, alert WhaleAlert) string {
+	base := baseAsset(alert.Symbol)
+	side := "🔴 " + i18n.T(lang, i18n.WhaleSell)
+	if alert.Buy {
+		side = "🟢 " + i18n.T(lang, i18n.WhaleBuy)
+	}
+	trades := ""
+	if alert.Trades > 1 {
+		trades = " " + i18n.T(lang, i18n.WhaleTrades, alert.Trades)
+	}
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("🐋 <b>%s / USDT</b>\n", escape(base)))
+		builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelSide), side))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code> %s (<code>%s</code> USDT)%s\n", i18n.T(lang, i18n.LabelSize), formatQuantity(lang, alert.Quantity), escape(base), i18n.FormatNumber(lang, alert.Notional, 0), trades))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.Price)))
+		builder.WriteString(links(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("🐋 %s / USDT %s <code>%s</code> USDT%s %s <a href=\"%s\">📊</a>\n",
+		symbolLink(alert.Symbol),
+		side,
+		i18n.FormatNumber(lang, alert.Notional, 0),
+		trades,
+		compactPrice(lang, alert.Price),
+		TradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderListingAlert(style Style, lang i18n.Language, alert ListingAlert) string {
+	icon, title := "🆕", i18n.T(lang, i18n.ListingNew)
+	switch alert.Kind {
+	case "trading":
+		icon, title = "🔔", i18n.T(lang, i18n.ListingTrading)
+	case "delisting":
+		icon, title = "⛔", i18n.T(lang, i18n.ListingDelisting)
+	}
+	status := escape(alert.Status)
+	if alert.PreviousStatus != "" {
+		status = escape(alert.PreviousStatus) + " → " + status
+	}
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("%s <b>%s / USDT</b>\n", icon, escape(baseAsset(alert.Symbol))))
+		builder.WriteString(fmt.Sprintf("<b>%s</b>\n", title))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelStatus), status))
+		builder.WriteString(links(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("%s %s / USDT %s (<code>%s</code>) <a href=\"%s\">📊</a>\n",
+		icon,
+		symbolLink(alert.Symbol),
+		title,
+		status,
+		TradingViewURL(alert.Symbol),
+	)
+}
+
+func RenderFundingAlert(style Style, lang i18n.Language, alert FundingAlert) string {
+	rate := formatFundingRate(lang, alert.FundingRate)
+	next := formatCountdown(alert.NextFunding)
+
+	if style == StyleVerbose {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("💸 <b>%s / USDT PERP</b>\n", escape(baseAsset(alert.Symbol))))
+		builder.WriteString(fmt.Sprintf("%s: <code>%s</code>\n", i18n.T(lang, i18n.LabelPrice), i18n.FormatPrice(lang, alert.MarkPrice)))
+		builder.WriteString(fmt.Sprintf("%s: <b>%s</b>, %s %s\n", i18n.T(lang, i18n.LabelFunding), rate, i18n.T(lang, i18n.LabelNextFunding), next))
+		if alert.Settled {
+			builder.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(lang, i18n.LabelSettledFunding), formatFundingRate(lang, alert.SettledRate)))
+		}
+		builder.WriteString(futuresLinks(alert.Symbol) + "\n")
+		return builder.String()
+	}
+
+	return fmt.Sprintf("💸 %s / USDT PERP %s FR: <b>%s</b> (%s) <a href=\"%s\">📊</a>\n",
+		futuresSymbolLink(alert.Symbol),
+		compactPrice(lang, alert.MarkPrice),
+		rate,
+		next,
+		FuturesTradingViewURL(alert.Symbol),
+	)
+}
+
+func BinanceURL(symbol string) string {
+	return fmt.Sprintf("https://www.binance.com/en/trade/%s_USDT?type=spot", baseAsset(symbol))
+}
+
+// TradeURL opens the spot market of the pair on the venue it came from.
+func TradeURL(symbol string) string {
+	exchange, pair := venue.Split(symbol)
+	base := baseAsset(pair)
+	switch exchange {
+	case "bybit":
+		return fmt.Sprintf("https://www.bybit.com/en/trade/spot/%s/USDT", base)
+	case "okx":
+		return fmt.Sprintf("https://www.okx.com/trade-spot/%s-usdt", strings.ToLower(base))
+	case "kucoin":
+		return fmt.Sprintf("https://www.kucoin.com/trade/%s-USDT", base)
+	default:
+		return BinanceURL(pair)
+	}
+}
+
+func TradingViewURL(symbol string) string {
+	exchange, pair := venue.Split(symbol)
+	return fmt.Sprintf("https://www.tradingview.com/chart/?symbol=%s:%s", strings.ToUpper(exchange), pair)
+}
+
+func BinanceFuturesURL(symbol string) string {
+	return fmt.Sprintf("https://www.binance.com/en/futures/%s", symbol)
+}
+
+func FuturesTradingViewURL(symbol string) string {
+	return fmt.Sprintf("https://www.tradingview.com/chart/?symbol=BINANCE:%s.P", symbol)
+}
+
+func PercentChange(from, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return (to/from - 1) * 100
+}
+
+// compactPrice and compactChange24h are the short price and 24h change
+// fields of the compact style.
+func compactPrice(lang i18n.Language, price float64) string {
+	return fmt.Sprintf("%s: <code>%s</code>", i18n.T(lang, i18n.LabelPriceShort), i18n.FormatPrice(lang, price))
+}
+
+func compactChange24h(lang i18n.Language, change float64) string {
+	return fmt.Sprintf("%s: %s", i18n.T(lang, i18n.LabelChange24hShort), i18n.FormatPercent(lang, change, false))
+}
+
+func symbolLink(symbol string) string {
+	return fmt.Sprintf("<a href=\"%s\"><b>%s</b></a>", TradeURL(symbol), escape(baseAsset(symbol)))
+}
+
+func links(symbol string) string {
+	exchange, _ := venue.Split(symbol)
+	return fmt.Sprintf("<a href=\"%s\">%s</a> | <a href=\"%s\">TradingView</a>", TradeURL(symbol), venueNames[exchange], TradingViewURL(symbol))
+}
+
+func futuresSymbolLink(symbol string) string {
+	return fmt.Sprintf("<a href=\"%s\"><b>%s</b></a>", BinanceFuturesURL(symbol), escape(baseAsset(symbol)))
+}
+
+func futuresLinks(symbol string) string {
+	return fmt.Sprintf("<a href=\"%s\">Binance Futures</a> | <a href=\"%s\">TradingView</a>", BinanceFuturesURL(symbol), FuturesTradingViewURL(symbol))
+}
+
+func formatQuantity(lang i18n.Language, quantity float64) string {
+	if quantity >= 100 {
+		return i18n.FormatNumber(lang, quantity, 0)
+	}
+	return i18n.FormatPrice(lang, quantity)
+}
+
+func formatFundingRate(lang i18n.Language, rate float64) string {
+	formatted := i18n.FormatNumber(lang, rate*100, 4) + "%"
+	if rate > 0 {
+		formatted = "+" + formatted
+	}
+	return formatted
+}
+
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+var venueNames = map[string]string{
+	"binance": "Binance",
+	"bybit":   "Bybit",
+	"okx":     "OKX",
+	"kucoin":  "KuCoin",
+}
+
+func trendEmoji(trend Trend) string {
+	switch trend {
+	case TrendUp:
+		return "📈"
+	case TrendDown:
+		return "📉"
+	case TrendNew:
+		return "✅"
+	default:
+		return "🔹"
+	}
+}
+
+// baseAsset drops the venue prefix too, the venue shows in the links.
+func baseAsset(symbol string) string {
+	_, pair := venue.Split(symbol)
+	return strings.TrimSuffix(pair, "USDT")
+}
+
+func escape(text string) string {
+	return html.EscapeString(text)
+}
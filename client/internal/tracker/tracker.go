@@ -1,6 +1,7 @@
 package tracker
 
 import (
+	"github.com/agopankov/imPulse/client/internal/baseline"
 	"sync"
 	"time"
 )
@@ -13,16 +14,26 @@ type SymbolChange struct {
 	AddedAt            time.Time
 	IsNew              bool
 	NotificationOfPump bool
+	LastPumpAt         time.Time
+	LastPumpPct        float64
+	PeakPrice          float64
+	VWAP               float64
+	VWAPAt             time.Time
+	// SuppressedAt is when a pump alert was last held back for a thin order
+	// book.
+	SuppressedAt time.Time
 }
 
 type Tracker struct {
 	mu             sync.Mutex
 	trackedSymbols map[string]SymbolChange
+	priceHistory   map[string][]baseline.PricePoint
 }
 
 func NewTracker() *Tracker {
 	return &Tracker{
 		trackedSymbols: make(map[string]SymbolChange),
+		priceHistory:   make(map[string][]baseline.PricePoint),
 	}
 }
 
@@ -47,6 +58,24 @@ func (t *Tracker) RemoveTrackedSymbol(symbol string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	delete(t.trackedSymbols, symbol)
+	delete(t.priceHistory, symbol)
+}
+
+func (t *Tracker) RecordPrice(symbol string, price float64, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	history := append(t.priceHistory[symbol], baseline.PricePoint{Time: at, Price: price})
+	expired := 0
+	for expired < len(history) && at.Sub(history[expired].Time) > baseline.MaxWindow {
+		expired++
+	}
+	t.priceHistory[symbol] = history[expired:]
+}
+
+func (t *Tracker) PriceHistory(symbol string) []baseline.PricePoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]baseline.PricePoint(nil), t.priceHistory[symbol]...)
 }
 
 func (t *Tracker) IsTracked(symbol string) bool {
@@ -1,7 +1,18 @@
 package user
 
 import (
+	"github.com/agopankov/imPulse/client/internal/baseline"
 	"github.com/agopankov/imPulse/client/internal/database"
+	"github.com/agopankov/imPulse/client/internal/futures"
+	"github.com/agopankov/imPulse/client/internal/i18n"
+	"github.com/agopankov/imPulse/client/internal/indicators"
+	"github.com/agopankov/imPulse/client/internal/quiethours"
+	"github.com/agopankov/imPulse/client/internal/rules"
+	"github.com/agopankov/imPulse/client/internal/templates"
+	"github.com/agopankov/imPulse/client/internal/venue"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,6 +25,7 @@ const (
 	StateAwaitingVerification
 	StateAwaitingPercent
 	StateAwaitingWaitTime
+	StateAwaitingPumpPercent
 )
 
 type UserManager struct {
@@ -26,10 +38,23 @@ type User struct {
 	mu              sync.Mutex
 	FirstChatID     int64
 	SecondChatID    int64
+	FirstThreadID   int
+	SecondThreadID  int
 	Email           string
 	State           State
+	MessageStyle    templates.Style
+	Language        i18n.Language
 	ChangePercent24 *ChangePercent24
 	PumpSettings    *PumpSettings
+	LiveBoard       *LiveBoard
+	QuietHours      *QuietHours
+	Indicators      *Indicators
+	Rules           *Rules
+	Exchanges       *Exchanges
+	Futures         *Futures
+	Liquidity       *Liquidity
+	Whales          *Whales
+	Listings        *Listings
 }
 
 type ChangePercent24 struct {
@@ -41,6 +66,129 @@ type PumpSettings struct {
 	mux         sync.Mutex
 	waitTime    time.Duration
 	pumpPercent float64
+	cooldown    time.Duration
+	steps       []float64
+	retrace     float64
+	baseline    baseline.Settings
+}
+
+type LiveBoard struct {
+	mu        sync.Mutex
+	enabled   bool
+	chatID    int64
+	messageID int
+	symbols   map[string]bool
+}
+
+type QuietHours struct {
+	mu            sync.Mutex
+	schedule      *quiethours.Schedule
+	urgentPercent float64
+	firstDigest   []string
+	secondDigest  []string
+	summary       string
+	held          map[HeldKind]int
+	dropped       map[HeldKind]int
+}
+
+// HeldKind tells apart the alerts held during quiet hours, each kind is
+// capped on its own. New coins go to the first chat, the rest to the second.
+type HeldKind int
+
+const (
+	HeldNewCoins HeldKind = iota
+	HeldPump
+	HeldRule
+	HeldIndicator
+	HeldFutures
+	HeldWhale
+)
+
+// HeldKinds lists the kinds in the order their counts end a digest.
+var HeldKinds = []HeldKind{HeldNewCoins, HeldPump, HeldRule, HeldIndicator, HeldFutures, HeldWhale}
+
+type IndicatorSubscription struct {
+	ID     int
+	Symbol string
+	Signal indicators.Signal
+}
+
+type Indicators struct {
+	mu            sync.Mutex
+	nextID        int
+	subscriptions []IndicatorSubscription
+	fired         map[string]int64
+}
+
+type Rule struct {
+	ID   int
+	Text string
+	Expr rules.Expr
+}
+
+// RuleCooldown is how long a rule stays quiet on a symbol after it fired, so
+// a value hovering around the threshold doesn't alert on every tick.
+const RuleCooldown = 15 * time.Minute
+
+type Rules struct {
+	mu      sync.Mutex
+	nextID  int
+	rules   []Rule
+	matched map[string]bool
+	firedAt map[string]time.Time
+}
+
+type Exchanges struct {
+	mu    sync.Mutex
+	names []string
+}
+
+var defaultFuturesSettings = futures.Settings{
+	OpenInterestPercent: 10,
+	OpenInterestWindow:  15 * time.Minute,
+	FundingPercent:      0.1,
+}
+
+type Futures struct {
+	mu       sync.Mutex
+	enabled  bool
+	settings futures.Settings
+}
+
+const defaultLiquidityBand = 2
+
+// Liquidity is the order book context of pump alerts: the notional within
+// ±band% is shown, and alerts on books thinner than minDepth USDT, both sides
+// together, are dropped. A zero minDepth never drops anything.
+type Liquidity struct {
+	mu       sync.Mutex
+	band     float64
+	minDepth float64
+}
+
+// WhaleSettings are USDT notional thresholds for single trades and for bursts
+// of trades on one side within BurstWindow, zero turns one of them off.
+type WhaleSettings struct {
+	MinNotional   float64
+	BurstNotional float64
+	BurstWindow   time.Duration
+}
+
+var defaultWhaleSettings = WhaleSettings{
+	MinNotional:   100000,
+	BurstNotional: 250000,
+	BurstWindow:   10 * time.Second,
+}
+
+type Whales struct {
+	mu       sync.Mutex
+	enabled  bool
+	settings WhaleSettings
+}
+
+type Listings struct {
+	mu      sync.Mutex
+	enabled bool
 }
 
 func NewUserManagerWithDB(db database.Database) *UserManager {
@@ -54,6 +202,16 @@ func NewUser() *User {
 	return &User{
 		ChangePercent24: &ChangePercent24{},
 		PumpSettings:    &PumpSettings{},
+		LiveBoard:       &LiveBoard{},
+		QuietHours:      &QuietHours{},
+		Indicators:      &Indicators{},
+		Rules:           &Rules{},
+		Exchanges:       &Exchanges{names: []string{venue.Default}},
+		Futures:         &Futures{settings: defaultFuturesSettings},
+		Liquidity:       &Liquidity{band: defaultLiquidityBand},
+		Whales:          &Whales{settings: defaultWhaleSettings},
+		Listings:        &Listings{},
+		Language:        i18n.English,
 	}
 }
 
@@ -93,6 +251,354 @@ func (p *PumpSettings) GetPumpPercent() float64 {
 	return p.pumpPercent
 }
 
+func (p *PumpSettings) SetCooldown(cooldown time.Duration) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.cooldown = cooldown
+}
+
+func (p *PumpSettings) GetCooldown() time.Duration {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.cooldown
+}
+
+func (p *PumpSettings) SetSteps(steps []float64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.steps = append([]float64(nil), steps...)
+	sort.Float64s(p.steps)
+}
+
+func (p *PumpSettings) GetSteps() []float64 {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return append([]float64(nil), p.steps...)
+}
+
+func (p *PumpSettings) SetRetrace(retrace float64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.retrace = retrace
+}
+
+func (p *PumpSettings) GetRetrace() float64 {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.retrace
+}
+
+func (p *PumpSettings) SetBaseline(settings baseline.Settings) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.baseline = settings
+}
+
+func (p *PumpSettings) GetBaseline() baseline.Settings {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.baseline
+}
+
+func (lb *LiveBoard) SetEnabled(enabled bool) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.enabled = enabled
+	if !enabled {
+		lb.chatID = 0
+		lb.messageID = 0
+		lb.symbols = nil
+	}
+}
+
+func (lb *LiveBoard) IsEnabled() bool {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.enabled
+}
+
+func (lb *LiveBoard) Restore(chatID int64, messageID int) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.enabled = true
+	lb.chatID = chatID
+	lb.messageID = messageID
+	lb.symbols = nil
+}
+
+func (lb *LiveBoard) SetMessage(chatID int64, messageID int, symbols []string) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.chatID = chatID
+	lb.messageID = messageID
+	lb.symbols = make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		lb.symbols[symbol] = true
+	}
+}
+
+func (lb *LiveBoard) GetMessage() (int64, int) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.chatID, lb.messageID
+}
+
+func (lb *LiveBoard) HasNewSymbols(symbols []string) bool {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	if lb.symbols == nil {
+		return false
+	}
+	for _, symbol := range symbols {
+		if !lb.symbols[symbol] {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *QuietHours) SetSchedule(schedule *quiethours.Schedule) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.schedule = schedule
+}
+
+func (q *QuietHours) GetSchedule() *quiethours.Schedule {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.schedule
+}
+
+func (q *QuietHours) IsActive(t time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.schedule != nil && q.schedule.Active(t)
+}
+
+func (q *QuietHours) SetUrgentPercent(urgentPercent float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.urgentPercent = urgentPercent
+}
+
+func (q *QuietHours) GetUrgentPercent() float64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.urgentPercent
+}
+
+// maxHeld is how many alerts of one kind a quiet hours digest holds. Past it
+// they are only counted, a busy night would bury the rest of the digest
+// otherwise.
+const maxHeld = 20
+
+// Hold holds alerts for the digest, each one a separate alert.
+func (q *QuietHours) Hold(kind HeldKind, alerts ...string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.held == nil {
+		q.held = make(map[HeldKind]int)
+		q.dropped = make(map[HeldKind]int)
+	}
+	for _, alert := range alerts {
+		if q.held[kind] >= maxHeld {
+			q.dropped[kind]++
+			continue
+		}
+		q.held[kind]++
+		if kind == HeldNewCoins {
+			q.firstDigest = append(q.firstDigest, alert)
+		} else {
+			q.secondDigest = append(q.secondDigest, alert)
+		}
+	}
+}
+
+func (q *QuietHours) HoldSummary(message string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.summary = message
+}
+
+// TakeDigest returns the held messages for both chats and the number of
+// alerts of each kind that were only counted.
+func (q *QuietHours) TakeDigest() (string, string, map[HeldKind]int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	first := strings.Join(q.firstDigest, "")
+	if q.summary != "" {
+		first += "\n" + q.summary
+	}
+	second := strings.Join(q.secondDigest, "")
+	dropped := q.dropped
+	q.firstDigest = nil
+	q.secondDigest = nil
+	q.summary = ""
+	q.held = nil
+	q.dropped = nil
+	return first, second, dropped
+}
+
+func (ind *Indicators) Add(symbol string, signal indicators.Signal) int {
+	ind.mu.Lock()
+	defer ind.mu.Unlock()
+	ind.nextID++
+	ind.subscriptions = append(ind.subscriptions, IndicatorSubscription{ID: ind.nextID, Symbol: symbol, Signal: signal})
+	return ind.nextID
+}
+
+func (ind *Indicators) Remove(id int) bool {
+	ind.mu.Lock()
+	defer ind.mu.Unlock()
+	for i, subscription := range ind.subscriptions {
+		if subscription.ID == id {
+			ind.subscriptions = append(ind.subscriptions[:i], ind.subscriptions[i+1:]...)
+			prefix := strconv.Itoa(id) + ":"
+			for key := range ind.fired {
+				if strings.HasPrefix(key, prefix) {
+					delete(ind.fired, key)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (ind *Indicators) List() []IndicatorSubscription {
+	ind.mu.Lock()
+	defer ind.mu.Unlock()
+	return append([]IndicatorSubscription(nil), ind.subscriptions...)
+}
+
+// Replace keeps the IDs of the subscriptions like Rules.Replace, so restored
+// ones can still be removed by the number /indicator list showed.
+func (ind *Indicators) Replace(list []IndicatorSubscription) {
+	ind.mu.Lock()
+	defer ind.mu.Unlock()
+	ind.subscriptions = nil
+	ind.fired = nil
+	ind.nextID = 0
+	for _, subscription := range list {
+		if subscription.ID > ind.nextID {
+			ind.nextID = subscription.ID
+		}
+	}
+	seen := make(map[int]bool, len(list))
+	for _, subscription := range list {
+		if subscription.ID <= 0 || seen[subscription.ID] {
+			ind.nextID++
+			subscription.ID = ind.nextID
+		}
+		seen[subscription.ID] = true
+		ind.subscriptions = append(ind.subscriptions, subscription)
+	}
+}
+
+func (ind *Indicators) MarkFired(id int, symbol string, candleOpenTime int64) bool {
+	ind.mu.Lock()
+	defer ind.mu.Unlock()
+	if ind.fired == nil {
+		ind.fired = make(map[string]int64)
+	}
+	key := strconv.Itoa(id) + ":" + symbol
+	if ind.fired[key] >= candleOpenTime {
+		return false
+	}
+	ind.fired[key] = candleOpenTime
+	return true
+}
+
+func (r *Rules) Add(text string, expr rules.Expr) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	r.rules = append(r.rules, Rule{ID: r.nextID, Text: text, Expr: expr})
+	return r.nextID
+}
+
+// Replace keeps the IDs of the rules, so restored rules can still be removed
+// by the IDs the user saw. Rules without an ID, or with one already taken,
+// get the next free ones.
+func (r *Rules) Replace(list []Rule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = nil
+	r.matched = nil
+	r.nextID = 0
+	for _, rule := range list {
+		if rule.ID > r.nextID {
+			r.nextID = rule.ID
+		}
+	}
+	seen := make(map[int]bool, len(list))
+	for _, rule := range list {
+		if rule.ID <= 0 || seen[rule.ID] {
+			r.nextID++
+			rule.ID = r.nextID
+		}
+		seen[rule.ID] = true
+		r.rules = append(r.rules, rule)
+	}
+}
+
+func (r *Rules) Remove(id int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, rule := range r.rules {
+		if rule.ID == id {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			prefix := strconv.Itoa(id) + ":"
+			for key := range r.matched {
+				if strings.HasPrefix(key, prefix) {
+					delete(r.matched, key)
+				}
+			}
+			for key := range r.firedAt {
+				if strings.HasPrefix(key, prefix) {
+					delete(r.firedAt, key)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rules) List() []Rule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Rule(nil), r.rules...)
+}
+
+// Match reports whether rule id should alert on symbol: it has to go from
+// not matching to matching, at least RuleCooldown after it last alerted.
+func (r *Rules) Match(id int, symbol string, matched bool, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.matched == nil {
+		r.matched = make(map[string]bool)
+		r.firedAt = make(map[string]time.Time)
+	}
+	key := strconv.Itoa(id) + ":" + symbol
+	wasMatched := r.matched[key]
+	firedAt, fired := r.firedAt[key]
+	if !matched {
+		delete(r.matched, key)
+		if fired && now.Sub(firedAt) >= RuleCooldown {
+			delete(r.firedAt, key)
+		}
+		return false
+	}
+
+	r.matched[key] = true
+	if wasMatched || (fired && now.Sub(firedAt) < RuleCooldown) {
+		return false
+	}
+	r.firedAt[key] = now
+	return true
+}
+
 func (u *User) SetState(state State) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -141,6 +647,150 @@ func (u *User) GetSecondChatID() int64 {
 	return u.SecondChatID
 }
 
+func (u *User) SetMessageStyle(style templates.Style) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.MessageStyle = style
+}
+
+func (u *User) GetMessageStyle() templates.Style {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.MessageStyle
+}
+
+func (u *User) SetLanguage(lang i18n.Language) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.Language = lang
+}
+
+func (u *User) GetLanguage() i18n.Language {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.Language
+}
+
+func (u *User) SetFirstThreadID(id int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.FirstThreadID = id
+}
+
+func (u *User) SetSecondThreadID(id int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.SecondThreadID = id
+}
+
+func (u *User) GetFirstThreadID() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.FirstThreadID
+}
+
+func (u *User) GetSecondThreadID() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.SecondThreadID
+}
+
+func (e *Exchanges) Set(names []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.names = append([]string(nil), names...)
+}
+
+func (e *Exchanges) Get() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.names...)
+}
+
+func (f *Futures) SetEnabled(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enabled = enabled
+}
+
+func (f *Futures) IsEnabled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enabled
+}
+
+func (f *Futures) SetSettings(settings futures.Settings) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.settings = settings
+}
+
+func (f *Futures) GetSettings() futures.Settings {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.settings
+}
+
+func (l *Liquidity) SetBand(band float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.band = band
+}
+
+func (l *Liquidity) GetBand() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.band
+}
+
+func (l *Liquidity) SetMinDepth(minDepth float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.minDepth = minDepth
+}
+
+func (l *Liquidity) GetMinDepth() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.minDepth
+}
+
+func (w *Whales) SetEnabled(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.enabled = enabled
+}
+
+func (w *Whales) IsEnabled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enabled
+}
+
+func (w *Whales) SetSettings(settings WhaleSettings) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.settings = settings
+}
+
+func (w *Whales) GetSettings() WhaleSettings {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.settings
+}
+
+func (l *Listings) SetEnabled(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.enabled = enabled
+}
+
+func (l *Listings) IsEnabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enabled
+}
+
 func (m *UserManager) GetUser(id int64) (*User, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/client/internal/indicators"
+)
+
+func TestRulesMatchCooldown(t *testing.T) {
+	r := &Rules{}
+	start := time.Unix(1700000000, 0)
+	steps := []struct {
+		after   time.Duration
+		matched bool
+		want    bool
+	}{
+		{0, true, true},
+		{5 * time.Second, true, false},
+		{10 * time.Second, false, false},
+		{15 * time.Second, true, false}, // hovering around the threshold
+		{20 * time.Second, false, false},
+		{RuleCooldown + time.Minute, true, true},
+		{RuleCooldown + 2*time.Minute, true, false},
+	}
+	for i, step := range steps {
+		if got := r.Match(1, "PEPEUSDT", step.matched, start.Add(step.after)); got != step.want {
+			t.Errorf("step %d: Match = %v, want %v", i, got, step.want)
+		}
+	}
+
+	if !r.Match(2, "PEPEUSDT", true, start) {
+		t.Error("rule #2 was held back by the cooldown of rule #1")
+	}
+}
+
+func TestRulesRemoveForgetsMatches(t *testing.T) {
+	r := &Rules{}
+	r.Replace([]Rule{{ID: 1, Text: "ch5m > 3"}, {ID: 12, Text: "ch1h > 3"}})
+	now := time.Unix(1700000000, 0)
+	r.Match(1, "PEPEUSDT", true, now)
+	r.Match(12, "PEPEUSDT", true, now)
+
+	if !r.Remove(1) {
+		t.Fatal("Remove(1) = false")
+	}
+	if len(r.matched) != 1 || len(r.firedAt) != 1 || !r.matched["12:PEPEUSDT"] {
+		t.Errorf("got matched %v and fired %v, want only rule #12 left", r.matched, r.firedAt)
+	}
+}
+
+func TestIndicatorsReplaceKeepsIDs(t *testing.T) {
+	ind := &Indicators{}
+	ind.Replace([]IndicatorSubscription{{ID: 3, Symbol: "PEPEUSDT"}, {ID: 0, Symbol: "DOGEUSDT"}, {ID: 3, Symbol: "WIFUSDT"}})
+
+	var ids []int
+	for _, subscription := range ind.List() {
+		ids = append(ids, subscription.ID)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 4 || ids[2] != 5 {
+		t.Errorf("got IDs %v, want [3 4 5]", ids)
+	}
+	if id := ind.Add("", indicators.Signal{}); id != 6 {
+		t.Errorf("next ID = %d, want 6", id)
+	}
+}
+
+func TestQuietHoursCapsEachKind(t *testing.T) {
+	q := &QuietHours{}
+	for i := 0; i < maxHeld+3; i++ {
+		q.Hold(HeldRule, "rule\n")
+	}
+	q.Hold(HeldPump, "pump\n", "pump\n")
+	q.Hold(HeldNewCoins, "coin\n")
+
+	first, second, dropped := q.TakeDigest()
+	if first != "coin\n" {
+		t.Errorf("first chat digest = %q", first)
+	}
+	if got := strings.Count(second, "rule\n"); got != maxHeld {
+		t.Errorf("held %d rule alerts, want %d", got, maxHeld)
+	}
+	if got := strings.Count(second, "pump\n"); got != 2 {
+		t.Errorf("held %d pump alerts, want 2", got)
+	}
+	if dropped[HeldRule] != 3 || dropped[HeldPump] != 0 {
+		t.Errorf("dropped = %v, want 3 rule alerts", dropped)
+	}
+
+	if first, second, dropped := q.TakeDigest(); first != "" || second != "" || len(dropped) != 0 {
+		t.Errorf("digest not cleared: %q, %q, %v", first, second, dropped)
+	}
+}
@@ -0,0 +1,37 @@
+package venue
+
+import (
+	"strings"
+)
+
+const Default = "binance"
+
+var Supported = []string{"binance", "bybit", "okx", "kucoin"}
+
+// Symbol names a pair on a venue. Binance pairs keep their plain name, pairs
+// from other venues are prefixed like BYBIT:PEPEUSDT so they never collide.
+func Symbol(exchange string, symbol string) string {
+	if exchange == "" || exchange == Default {
+		return symbol
+	}
+	return strings.ToUpper(exchange) + ":" + symbol
+}
+
+// Split is the reverse of Symbol.
+func Split(symbol string) (string, string) {
+	exchange, pair, ok := strings.Cut(symbol, ":")
+	if !ok {
+		return Default, symbol
+	}
+	return strings.ToLower(exchange), pair
+}
+
+func Parse(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, supported := range Supported {
+		if name == supported {
+			return name, true
+		}
+	}
+	return "", false
+}
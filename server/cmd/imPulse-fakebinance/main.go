@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"github.com/agopankov/imPulse/server/pkg/fakebinance"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	scriptPath := flag.String("script", "", "JSON fixture with frames and klines")
+	addr := flag.String("addr", ":8090", "address to listen on")
+	advance := flag.Duration("advance", 0, "move to the next frame on this interval, 0 waits for POST /fake/advance")
+	flag.Parse()
+
+	if *scriptPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	script, err := fakebinance.LoadScript(*scriptPath)
+	if err != nil {
+		log.Fatalf("Failed to load script: %v", err)
+	}
+
+	server, err := fakebinance.New(script)
+	if err != nil {
+		log.Fatalf("Failed to create fake Binance: %v", err)
+	}
+	if err := server.Start(*addr); err != nil {
+		log.Fatalf("Failed to start fake Binance: %v", err)
+	}
+	log.Printf("Fake Binance serving %d frames at %s (streams at %s)", len(script.Frames), server.URL(), server.StreamURL())
+
+	if *advance > 0 {
+		go func() {
+			ticker := time.NewTicker(*advance)
+			defer ticker.Stop()
+			for range ticker.C {
+				if !server.Advance() {
+					log.Println("Reached the last frame")
+					return
+				}
+				log.Printf("Advanced to frame %d", server.Frame())
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	if err := server.Close(); err != nil {
+		log.Printf("Failed to close fake Binance: %v", err)
+	}
+}
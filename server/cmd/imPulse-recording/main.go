@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"github.com/agopankov/imPulse/server/pkg/replay"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type filter struct {
+	symbols map[string]bool
+	from    time.Time
+	to      time.Time
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "inspect":
+		inspect(os.Args[2:])
+	case "export":
+		export(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n  %[1]s inspect [-symbol BTCUSDT,ETHUSDT] [-from RFC3339] [-to RFC3339] files...\n  %[1]s export [-symbol BTCUSDT] [-from RFC3339] [-to RFC3339] [-out file.csv] files...\n", os.Args[0])
+	os.Exit(2)
+}
+
+func inspect(args []string) {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	snapshotFilter := filterFlags(flags)
+	_ = flags.Parse(args)
+
+	snapshots := load(flags.Args(), snapshotFilter())
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots")
+		return
+	}
+
+	symbols := make(map[string]int)
+	for _, snapshot := range snapshots {
+		for _, price := range snapshot.Prices {
+			symbols[price.Symbol]++
+		}
+	}
+
+	fmt.Printf("Snapshots: %d\n", len(snapshots))
+	fmt.Printf("From:      %s\n", snapshots[0].Time.UTC().Format(time.RFC3339))
+	fmt.Printf("To:        %s\n", snapshots[len(snapshots)-1].Time.UTC().Format(time.RFC3339))
+	fmt.Printf("Symbols:   %d\n", len(symbols))
+
+	names := make([]string, 0, len(symbols))
+	for symbol := range symbols {
+		names = append(names, symbol)
+	}
+	sort.Strings(names)
+	if len(names) <= 20 {
+		for _, symbol := range names {
+			fmt.Printf("  %-12s %d prices\n", symbol, symbols[symbol])
+		}
+	}
+}
+
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	snapshotFilter := filterFlags(flags)
+	out := flags.String("out", "", "CSV file to write, stdout when empty")
+	_ = flags.Parse(args)
+
+	var writer io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write([]string{"time", "symbol", "price", "change_percent", "quote_volume"}); err != nil {
+		log.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	for _, snapshot := range load(flags.Args(), snapshotFilter()) {
+		tickers := make(map[string]replay.Ticker, len(snapshot.Tickers))
+		for _, ticker := range snapshot.Tickers {
+			tickers[ticker.Symbol] = ticker
+		}
+		for _, price := range snapshot.Prices {
+			ticker := tickers[price.Symbol]
+			record := []string{
+				snapshot.Time.UTC().Format(time.RFC3339Nano),
+				price.Symbol,
+				strconv.FormatFloat(price.Price, 'f', -1, 64),
+				strconv.FormatFloat(ticker.ChangePercent, 'f', -1, 64),
+				strconv.FormatFloat(ticker.QuoteVolume, 'f', -1, 64),
+			}
+			if err := csvWriter.Write(record); err != nil {
+				log.Fatalf("Failed to write CSV: %v", err)
+			}
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		log.Fatalf("Failed to write CSV: %v", err)
+	}
+}
+
+func filterFlags(flags *flag.FlagSet) func() filter {
+	symbols := flags.String("symbol", "", "comma separated symbols to keep")
+	from := flags.String("from", "", "keep snapshots at or after this RFC3339 time")
+	to := flags.String("to", "", "keep snapshots at or before this RFC3339 time")
+
+	return func() filter {
+		var result filter
+		if *symbols != "" {
+			result.symbols = make(map[string]bool)
+			for _, symbol := range strings.Split(*symbols, ",") {
+				result.symbols[strings.ToUpper(strings.TrimSpace(symbol))] = true
+			}
+		}
+		result.from = parseTime(*from)
+		result.to = parseTime(*to)
+		return result
+	}
+}
+
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Fatalf("Invalid time %q: %v", value, err)
+	}
+	return t
+}
+
+func load(paths []string, snapshotFilter filter) []replay.Snapshot {
+	if len(paths) == 0 {
+		usage()
+	}
+
+	snapshots, err := replay.Load(paths)
+	if err != nil {
+		log.Fatalf("Failed to load recordings: %v", err)
+	}
+
+	filtered := snapshots[:0]
+	for _, snapshot := range snapshots {
+		if !snapshotFilter.from.IsZero() && snapshot.Time.Before(snapshotFilter.from) {
+			continue
+		}
+		if !snapshotFilter.to.IsZero() && snapshot.Time.After(snapshotFilter.to) {
+			continue
+		}
+		if snapshotFilter.symbols != nil {
+			snapshot.Prices = filterPrices(snapshot.Prices, snapshotFilter.symbols)
+			snapshot.Tickers = filterTickers(snapshot.Tickers, snapshotFilter.symbols)
+		}
+		filtered = append(filtered, snapshot)
+	}
+	return filtered
+}
+
+func filterPrices(prices []replay.Price, symbols map[string]bool) []replay.Price {
+	var result []replay.Price
+	for _, price := range prices {
+		if symbols[price.Symbol] {
+			result = append(result, price)
+		}
+	}
+	return result
+}
+
+func filterTickers(tickers []replay.Ticker, symbols map[string]bool) []replay.Ticker {
+	var result []replay.Ticker
+	for _, ticker := range tickers {
+		if symbols[ticker.Symbol] {
+			result = append(result, ticker)
+		}
+	}
+	return result
+}
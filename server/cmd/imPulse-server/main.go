@@ -1,27 +1,97 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"github.com/agopankov/imPulse/server/pkg/exchange"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"github.com/agopankov/imPulse/server/pkg/listings"
+	"github.com/agopankov/imPulse/server/pkg/recorder"
+	"github.com/agopankov/imPulse/server/pkg/replay"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
+const shutdownTimeout = 5 * time.Second
+
 type SecretKeys struct {
 	BinanceAPIKey    string `json:"BINANCE_API_KEY"`
 	BinanceSecretKey string `json:"BINANCE_SECRET_KEY"`
 }
 
 func main() {
+	grpcServer := grpc.NewServer()
+
+	var snapshotRecorder *recorder.Recorder
+	if replayFiles := os.Getenv("REPLAY_FILES"); replayFiles != "" {
+		registerReplay(grpcServer, replayFiles)
+	} else {
+		if recordDir := os.Getenv("RECORD_DIR"); recordDir != "" {
+			snapshotRecorder = newRecorder(recordDir)
+		}
+		registerBinance(grpcServer, snapshotRecorder)
+	}
+
+	listener, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		log.Fatalf("Failed to listen on port 50051: %v", err)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Failed to serve gRPC server: %v", err)
+		}
+	}()
+
+	log.Println("gRPC server started successfully")
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	stopServer(grpcServer)
+	if snapshotRecorder != nil {
+		if err := snapshotRecorder.Close(); err != nil {
+			log.Printf("Failed to close recorder: %v", err)
+		}
+	}
+}
+
+// stopServer lets unary calls finish, but the whale, listing and order book
+// streams stay open as long as their clients do, so after shutdownTimeout the
+// remaining RPCs are cut.
+func stopServer(grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		log.Printf("Streams still open after %s, stopping", shutdownTimeout)
+		grpcServer.Stop()
+	}
+}
+
+func registerBinance(grpcServer *grpc.Server, snapshotRecorder *recorder.Recorder) {
 	var secrets SecretKeys
 
 	apiKey := os.Getenv("BINANCE_API_KEY")
 	secretKey := os.Getenv("BINANCE_SECRET_KEY")
+	baseURL := os.Getenv("BINANCE_BASE_URL")
 
-	if apiKey == "" || secretKey == "" {
+	if baseURL == "" && (apiKey == "" || secretKey == "") {
 		secretsFile, err := os.ReadFile("/mnt/secrets-store/prod_binance_secret")
 		if err != nil {
 			log.Fatalf("Failed to read secrets file: %v", err)
@@ -35,21 +105,122 @@ func main() {
 	}
 
 	server := grpcbinance.NewBinanceServiceServer(apiKey, secretKey)
-
-	grpcServer := grpc.NewServer()
+	if baseURL != "" {
+		server.SetBaseURL(baseURL)
+		log.Printf("Using Binance API at %s", baseURL)
+	}
+	if streamURL := os.Getenv("BINANCE_STREAM_URL"); streamURL != "" {
+		server.SetStreamURL(streamURL)
+		log.Printf("Using Binance streams at %s", streamURL)
+	}
+	if snapshotRecorder != nil {
+		server.Record(context.Background(), snapshotRecorder, recordInterval())
+	}
+	for _, venue := range extraExchanges() {
+		server.AddExchange(venue)
+	}
+	server.WatchListings(context.Background(), listingsInterval())
 	proto.RegisterBinanceServiceServer(grpcServer, server)
 
-	listener, err := net.Listen("tcp", ":50051")
+	futuresServer := grpcbinance.NewFuturesServiceServer(apiKey, secretKey)
+	if futuresURL := os.Getenv("BINANCE_FUTURES_BASE_URL"); futuresURL != "" {
+		futuresServer.SetBaseURL(futuresURL)
+	}
+	proto.RegisterFuturesServiceServer(grpcServer, futuresServer)
+}
+
+// extraExchanges builds the venues besides Binance listed in EXCHANGES, all
+// of them when it is empty. EXCHANGES=binance serves Binance only.
+func extraExchanges() []exchange.Exchange {
+	names := os.Getenv("EXCHANGES")
+	if names == "" {
+		names = "bybit,okx,kucoin"
+	}
+
+	var venues []exchange.Exchange
+	for _, name := range strings.Split(names, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "binance":
+		case "bybit":
+			venues = append(venues, exchange.NewBybit(os.Getenv("BYBIT_BASE_URL")))
+		case "okx":
+			venues = append(venues, exchange.NewOKX(os.Getenv("OKX_BASE_URL")))
+		case "kucoin":
+			venues = append(venues, exchange.NewKuCoin(os.Getenv("KUCOIN_BASE_URL")))
+		default:
+			log.Fatalf("Unknown exchange in EXCHANGES: %s", name)
+		}
+	}
+	return venues
+}
+
+func listingsInterval() time.Duration {
+	value := os.Getenv("LISTINGS_INTERVAL")
+	if value == "" {
+		return listings.DefaultInterval
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Fatalf("Invalid LISTINGS_INTERVAL: %q", value)
+	}
+	return interval
+}
+
+func recordInterval() time.Duration {
+	value := os.Getenv("RECORD_INTERVAL")
+	if value == "" {
+		return recorder.DefaultInterval
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Fatalf("Invalid RECORD_INTERVAL: %q", value)
+	}
+	return interval
+}
+
+func newRecorder(recordDir string) *recorder.Recorder {
+	rotate := time.Hour
+	if value := os.Getenv("RECORD_ROTATE"); value != "" {
+		var err error
+		rotate, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid RECORD_ROTATE: %v", err)
+		}
+	}
+
+	snapshotRecorder, err := recorder.New(recordDir, rotate)
 	if err != nil {
-		log.Fatalf("Failed to listen on port 50051: %v", err)
+		log.Fatalf("Failed to create recorder: %v", err)
 	}
+	return snapshotRecorder
+}
 
-	go func() {
-		if err := grpcServer.Serve(listener); err != nil {
-			log.Fatalf("Failed to serve gRPC server: %v", err)
+func registerReplay(grpcServer *grpc.Server, replayFiles string) {
+	speed := 1.0
+	if value := os.Getenv("REPLAY_SPEED"); value != "" {
+		var err error
+		speed, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			log.Fatalf("Invalid REPLAY_SPEED: %v", err)
 		}
-	}()
+	}
 
-	log.Println("gRPC server started successfully")
-	select {}
+	snapshots, err := replay.Load(strings.Split(replayFiles, ","))
+	if err != nil {
+		log.Fatalf("Failed to load replay files: %v", err)
+	}
+
+	server, control, err := replay.NewServer(snapshots, speed)
+	if err != nil {
+		log.Fatalf("Failed to start replay: %v", err)
+	}
+
+	proto.RegisterBinanceServiceServer(grpcServer, server)
+	proto.RegisterFuturesServiceServer(grpcServer, replay.Futures{})
+	proto.RegisterReplayControlServer(grpcServer, control)
+	reflection.Register(grpcServer)
+	log.Printf("Serving %s at %.2fx speed", server, speed)
+	log.Printf("Recordings hold no volume, the VWAP baseline has nothing to average in a replay")
 }
@@ -0,0 +1,234 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/adshao/go-binance/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const binanceStreamURL = "wss://stream.binance.com:9443/ws"
+
+type Binance struct {
+	client    *binance.Client
+	streamURL string
+}
+
+func NewBinance(apiKey, secretKey string) *Binance {
+	return &Binance{client: binance.NewClient(apiKey, secretKey), streamURL: binanceStreamURL}
+}
+
+func (b *Binance) Name() string {
+	return "binance"
+}
+
+func (b *Binance) SetBaseURL(baseURL string) {
+	b.client.BaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// SetStreamURL points the websocket streams somewhere else, the raw stream
+// endpoint ending in /ws.
+func (b *Binance) SetStreamURL(streamURL string) {
+	b.streamURL = strings.TrimSuffix(streamURL, "/")
+}
+
+func (b *Binance) Prices(ctx context.Context) ([]Price, error) {
+	prices, err := b.client.NewListPricesService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Price, 0, len(prices))
+	for _, price := range prices {
+		priceFloat, _ := strconv.ParseFloat(price.Price, 64)
+		result = append(result, Price{Symbol: price.Symbol, Price: priceFloat})
+	}
+	return result, nil
+}
+
+func (b *Binance) Tickers(ctx context.Context) ([]Ticker, error) {
+	stats, err := b.client.NewListPriceChangeStatsService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers := make([]Ticker, 0, len(stats))
+	for _, stat := range stats {
+		change, err := strconv.ParseFloat(stat.PriceChangePercent, 64)
+		if err != nil {
+			continue
+		}
+		tickers = append(tickers, Ticker{
+			Symbol:        stat.Symbol,
+			Price:         parseFloat(stat.LastPrice),
+			ChangePercent: change,
+			QuoteVolume:   parseFloat(stat.QuoteVolume),
+		})
+	}
+	return tickers, nil
+}
+
+func (b *Binance) Klines(ctx context.Context, query KlinesQuery) ([]Kline, error) {
+	service := b.client.NewKlinesService().Symbol(query.Symbol).Interval(query.Interval)
+	if query.StartTime > 0 {
+		service = service.StartTime(query.StartTime)
+	}
+	if query.EndTime > 0 {
+		service = service.EndTime(query.EndTime)
+	}
+	if query.Limit > 0 {
+		service = service.Limit(query.Limit)
+	}
+
+	klines, err := service.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Kline, 0, len(klines))
+	for _, kline := range klines {
+		result = append(result, Kline{
+			OpenTime:    kline.OpenTime,
+			Open:        parseFloat(kline.Open),
+			High:        parseFloat(kline.High),
+			Low:         parseFloat(kline.Low),
+			Close:       parseFloat(kline.Close),
+			Volume:      parseFloat(kline.Volume),
+			QuoteVolume: parseFloat(kline.QuoteAssetVolume),
+			CloseTime:   kline.CloseTime,
+		})
+	}
+	return result, nil
+}
+
+func (b *Binance) Symbols(ctx context.Context) ([]Symbol, error) {
+	info, err := b.client.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]Symbol, 0, len(info.Symbols))
+	for _, symbol := range info.Symbols {
+		symbols = append(symbols, Symbol{
+			Symbol:     symbol.Symbol,
+			BaseAsset:  symbol.BaseAsset,
+			QuoteAsset: symbol.QuoteAsset,
+			Trading:    symbol.Status == string(binance.SymbolStatusTypeTrading),
+			Status:     symbol.Status,
+		})
+	}
+	return symbols, nil
+}
+
+func (b *Binance) OrderBook(ctx context.Context, symbol string, limit int) (OrderBook, error) {
+	depth, err := b.client.NewDepthService().Symbol(symbol).Limit(limit).Do(ctx)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	book := OrderBook{
+		Symbol:       symbol,
+		Bids:         make([]Level, 0, len(depth.Bids)),
+		Asks:         make([]Level, 0, len(depth.Asks)),
+		LastUpdateID: depth.LastUpdateID,
+		Time:         time.Now().UnixMilli(),
+		Limit:        limit,
+	}
+	for _, bid := range depth.Bids {
+		book.Bids = append(book.Bids, Level{Price: parseFloat(bid.Price), Quantity: parseFloat(bid.Quantity)})
+	}
+	for _, ask := range depth.Asks {
+		book.Asks = append(book.Asks, Level{Price: parseFloat(ask.Price), Quantity: parseFloat(ask.Quantity)})
+	}
+	return book, nil
+}
+
+type binancePartialDepth struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+// StreamOrderBook follows the partial depth stream of the top levels (5, 10
+// or 20), one snapshot a second, until the context ends or handle fails.
+func (b *Binance) StreamOrderBook(ctx context.Context, symbol string, levels int, handle func(OrderBook) error) error {
+	endpoint := fmt.Sprintf("%s/%s@depth%d", b.streamURL, strings.ToLower(symbol), levels)
+	return stream(ctx, endpoint, func(message []byte) error {
+		var depth binancePartialDepth
+		if err := json.Unmarshal(message, &depth); err != nil {
+			return err
+		}
+
+		book := OrderBook{
+			Symbol:       symbol,
+			Bids:         make([]Level, 0, len(depth.Bids)),
+			Asks:         make([]Level, 0, len(depth.Asks)),
+			LastUpdateID: depth.LastUpdateID,
+			Time:         time.Now().UnixMilli(),
+			Limit:        levels,
+		}
+		for _, bid := range depth.Bids {
+			book.Bids = append(book.Bids, Level{Price: parseFloat(bid[0]), Quantity: parseFloat(bid[1])})
+		}
+		for _, ask := range depth.Asks {
+			book.Asks = append(book.Asks, Level{Price: parseFloat(ask[0]), Quantity: parseFloat(ask[1])})
+		}
+		return handle(book)
+	})
+}
+
+type AggTrade struct {
+	Symbol     string
+	Price      float64
+	Quantity   float64
+	BuyerMaker bool
+	Time       int64
+}
+
+// binanceAggTrade keeps the ignored "M" field, json matches keys case
+// insensitively and would otherwise read it into "m".
+type binanceAggTrade struct {
+	Symbol     string `json:"s"`
+	Price      string `json:"p"`
+	Quantity   string `json:"q"`
+	Time       int64  `json:"T"`
+	BuyerMaker bool   `json:"m"`
+	Ignore     bool   `json:"M"`
+}
+
+type binanceCombinedMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// StreamAggTrades follows the aggregate trades of all symbols over one
+// combined stream until the context ends or handle fails.
+func (b *Binance) StreamAggTrades(ctx context.Context, symbols []string, handle func(AggTrade) error) error {
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@aggTrade")
+	}
+	endpoint := strings.TrimSuffix(b.streamURL, "/ws") + "/stream?streams=" + strings.Join(streams, "/")
+
+	return stream(ctx, endpoint, func(message []byte) error {
+		var combined binanceCombinedMessage
+		if err := json.Unmarshal(message, &combined); err != nil {
+			return err
+		}
+		var trade binanceAggTrade
+		if err := json.Unmarshal(combined.Data, &trade); err != nil {
+			return err
+		}
+
+		return handle(AggTrade{
+			Symbol:     trade.Symbol,
+			Price:      parseFloat(trade.Price),
+			Quantity:   parseFloat(trade.Quantity),
+			BuyerMaker: trade.BuyerMaker,
+			Time:       trade.Time,
+		})
+	})
+}
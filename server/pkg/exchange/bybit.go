@@ -0,0 +1,168 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const BybitBaseURL = "https://api.bybit.com"
+
+var bybitIntervals = map[string]string{
+	"1m":  "1",
+	"3m":  "3",
+	"5m":  "5",
+	"15m": "15",
+	"30m": "30",
+	"1h":  "60",
+	"2h":  "120",
+	"4h":  "240",
+	"6h":  "360",
+	"12h": "720",
+	"1d":  "D",
+}
+
+// Bybit reads the v5 spot market endpoints.
+type Bybit struct {
+	baseURL string
+	tickers tickerCache
+}
+
+type bybitResponse[T any] struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List T `json:"list"`
+	} `json:"result"`
+}
+
+func NewBybit(baseURL string) *Bybit {
+	if baseURL == "" {
+		baseURL = BybitBaseURL
+	}
+	return &Bybit{baseURL: baseURL}
+}
+
+func (b *Bybit) Name() string {
+	return "bybit"
+}
+
+func (b *Bybit) Prices(ctx context.Context) ([]Price, error) {
+	tickers, err := b.Tickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pricesOf(tickers), nil
+}
+
+func (b *Bybit) Tickers(ctx context.Context) ([]Ticker, error) {
+	return b.tickers.get(ctx, b.fetchTickers)
+}
+
+func (b *Bybit) fetchTickers(ctx context.Context) ([]Ticker, error) {
+	var resp bybitResponse[[]struct {
+		Symbol       string `json:"symbol"`
+		LastPrice    string `json:"lastPrice"`
+		Price24hPcnt string `json:"price24hPcnt"`
+		Turnover24h  string `json:"turnover24h"`
+	}]
+	if err := b.get(ctx, "/v5/market/tickers", url.Values{"category": {"spot"}}, &resp); err != nil {
+		return nil, err
+	}
+
+	tickers := make([]Ticker, 0, len(resp.Result.List))
+	for _, item := range resp.Result.List {
+		tickers = append(tickers, Ticker{
+			Symbol:        item.Symbol,
+			Price:         parseFloat(item.LastPrice),
+			ChangePercent: parseFloat(item.Price24hPcnt) * 100,
+			QuoteVolume:   parseFloat(item.Turnover24h),
+		})
+	}
+	return tickers, nil
+}
+
+func (b *Bybit) Klines(ctx context.Context, query KlinesQuery) ([]Kline, error) {
+	interval, ok := bybitIntervals[query.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedInterval, query.Interval)
+	}
+	duration, err := intervalDuration(query.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"category": {"spot"}, "symbol": {query.Symbol}, "interval": {interval}}
+	if query.StartTime > 0 {
+		params.Set("start", strconv.FormatInt(query.StartTime, 10))
+	}
+	if query.EndTime > 0 {
+		params.Set("end", strconv.FormatInt(query.EndTime, 10))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	var resp bybitResponse[[][]string]
+	if err := b.get(ctx, "/v5/market/kline", params, &resp); err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(resp.Result.List))
+	for _, row := range resp.Result.List {
+		if len(row) < 7 {
+			continue
+		}
+		openTime := parseInt(row[0])
+		klines = append(klines, Kline{
+			OpenTime:    openTime,
+			Open:        parseFloat(row[1]),
+			High:        parseFloat(row[2]),
+			Low:         parseFloat(row[3]),
+			Close:       parseFloat(row[4]),
+			Volume:      parseFloat(row[5]),
+			QuoteVolume: parseFloat(row[6]),
+			CloseTime:   openTime + duration.Milliseconds() - 1,
+		})
+	}
+	return newestLast(klines, query.Limit), nil
+}
+
+func (b *Bybit) Symbols(ctx context.Context) ([]Symbol, error) {
+	var resp bybitResponse[[]struct {
+		Symbol    string `json:"symbol"`
+		BaseCoin  string `json:"baseCoin"`
+		QuoteCoin string `json:"quoteCoin"`
+		Status    string `json:"status"`
+	}]
+	if err := b.get(ctx, "/v5/market/instruments-info", url.Values{"category": {"spot"}}, &resp); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]Symbol, 0, len(resp.Result.List))
+	for _, item := range resp.Result.List {
+		symbols = append(symbols, Symbol{
+			Symbol:     item.Symbol,
+			BaseAsset:  item.BaseCoin,
+			QuoteAsset: item.QuoteCoin,
+			Trading:    item.Status == "Trading",
+			Status:     item.Status,
+		})
+	}
+	return symbols, nil
+}
+
+func (b *Bybit) get(ctx context.Context, path string, query url.Values, resp interface{ err() error }) error {
+	if err := getJSON(ctx, b.baseURL, path, query, resp); err != nil {
+		return err
+	}
+	return resp.err()
+}
+
+func (r *bybitResponse[T]) err() error {
+	if r.RetCode != 0 {
+		return fmt.Errorf("bybit error %d: %s", r.RetCode, r.RetMsg)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultName = "binance"
+
+var ErrUnsupportedInterval = errors.New("unsupported kline interval")
+
+// Exchange is the public market data one venue offers. Symbols are always
+// returned in the Binance style, base and quote asset glued together
+// (BTCUSDT), whatever the venue calls them, and intervals are Binance
+// interval names (1m, 5m, 15m, 1h, 4h, 1d).
+type Exchange interface {
+	Name() string
+	Prices(ctx context.Context) ([]Price, error)
+	Tickers(ctx context.Context) ([]Ticker, error)
+	Klines(ctx context.Context, query KlinesQuery) ([]Kline, error)
+	Symbols(ctx context.Context) ([]Symbol, error)
+}
+
+type Price struct {
+	Symbol string
+	Price  float64
+}
+
+type Ticker struct {
+	Symbol        string
+	Price         float64
+	ChangePercent float64
+	QuoteVolume   float64
+}
+
+type KlinesQuery struct {
+	Symbol    string
+	Interval  string
+	StartTime int64
+	EndTime   int64
+	Limit     int
+}
+
+type Kline struct {
+	OpenTime    int64
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	QuoteVolume float64
+	CloseTime   int64
+}
+
+// Status is the venue's own name for the symbol state, like TRADING or BREAK
+// on Binance, and empty on venues that only report whether it trades.
+type Symbol struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	Trading    bool
+	Status     string
+}
+
+var intervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// Registry holds the venues the server can serve, keyed by lower case name.
+type Registry struct {
+	exchanges map[string]Exchange
+}
+
+func NewRegistry(exchanges ...Exchange) *Registry {
+	registry := &Registry{exchanges: make(map[string]Exchange)}
+	for _, exchange := range exchanges {
+		registry.Add(exchange)
+	}
+	return registry
+}
+
+func (r *Registry) Add(exchange Exchange) {
+	r.exchanges[exchange.Name()] = exchange
+}
+
+// Get looks the venue up by name, an empty name means Binance.
+func (r *Registry) Get(name string) (Exchange, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultName
+	}
+
+	exchange, ok := r.exchanges[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown exchange %q, supported: %s", name, strings.Join(r.Names(), ", "))
+	}
+	return exchange, nil
+}
+
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.exchanges))
+	for name := range r.exchanges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func intervalDuration(interval string) (time.Duration, error) {
+	duration, ok := intervals[interval]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedInterval, interval)
+	}
+	return duration, nil
+}
+
+// joinSymbol turns BTC-USDT style names into BTCUSDT.
+func joinSymbol(symbol string) string {
+	return strings.ReplaceAll(symbol, "-", "")
+}
+
+// splitSymbol turns BTCUSDT into the BTC-USDT style OKX and KuCoin expect.
+func splitSymbol(symbol string) string {
+	for _, quote := range []string{"USDT", "USDC", "BTC", "ETH"} {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote) + "-" + quote
+		}
+	}
+	return symbol
+}
+
+// newestLast reverses klines that the venue returns newest first and trims
+// them to the query limit.
+func newestLast(klines []Kline, limit int) []Kline {
+	sort.Slice(klines, func(i, j int) bool {
+		return klines[i].OpenTime < klines[j].OpenTime
+	})
+	if limit > 0 && len(klines) > limit {
+		klines = klines[len(klines)-limit:]
+	}
+	return klines
+}
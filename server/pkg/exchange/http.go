@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tickerTTL is how long a ticker response is reused. Clients ask for prices
+// and 24h changes right after each other, venues without a separate price
+// endpoint answer both from one response.
+const tickerTTL = 2 * time.Second
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+type tickerCache struct {
+	mu        sync.Mutex
+	tickers   []Ticker
+	fetchedAt time.Time
+}
+
+func (c *tickerCache) get(ctx context.Context, fetch func(ctx context.Context) ([]Ticker, error)) ([]Ticker, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tickers != nil && time.Since(c.fetchedAt) < tickerTTL {
+		return c.tickers, nil
+	}
+
+	tickers, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tickers = tickers
+	c.fetchedAt = time.Now()
+	return tickers, nil
+}
+
+func pricesOf(tickers []Ticker) []Price {
+	prices := make([]Price, 0, len(tickers))
+	for _, ticker := range tickers {
+		prices = append(prices, Price{Symbol: ticker.Symbol, Price: ticker.Price})
+	}
+	return prices
+}
+
+func getJSON(ctx context.Context, baseURL string, path string, query url.Values, out interface{}) error {
+	endpoint := strings.TrimSuffix(baseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+func parseFloat(value string) float64 {
+	number, _ := strconv.ParseFloat(value, 64)
+	return number
+}
+
+func parseInt(value string) int64 {
+	number, _ := strconv.ParseInt(value, 10, 64)
+	return number
+}
@@ -0,0 +1,190 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const KuCoinBaseURL = "https://api.kucoin.com"
+
+var kucoinIntervals = map[string]string{
+	"1m":  "1min",
+	"3m":  "3min",
+	"5m":  "5min",
+	"15m": "15min",
+	"30m": "30min",
+	"1h":  "1hour",
+	"2h":  "2hour",
+	"4h":  "4hour",
+	"6h":  "6hour",
+	"12h": "12hour",
+	"1d":  "1day",
+}
+
+// KuCoin reads the public spot market endpoints. KuCoin candle times are in
+// seconds, they are converted to milliseconds like the other venues.
+type KuCoin struct {
+	baseURL string
+	tickers tickerCache
+}
+
+type kucoinResponse[T any] struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data T      `json:"data"`
+}
+
+func NewKuCoin(baseURL string) *KuCoin {
+	if baseURL == "" {
+		baseURL = KuCoinBaseURL
+	}
+	return &KuCoin{baseURL: baseURL}
+}
+
+func (k *KuCoin) Name() string {
+	return "kucoin"
+}
+
+func (k *KuCoin) Prices(ctx context.Context) ([]Price, error) {
+	tickers, err := k.Tickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pricesOf(tickers), nil
+}
+
+func (k *KuCoin) Tickers(ctx context.Context) ([]Ticker, error) {
+	return k.tickers.get(ctx, k.fetchTickers)
+}
+
+func (k *KuCoin) fetchTickers(ctx context.Context) ([]Ticker, error) {
+	var resp kucoinResponse[struct {
+		Ticker []struct {
+			Symbol     string `json:"symbol"`
+			Last       string `json:"last"`
+			ChangeRate string `json:"changeRate"`
+			VolValue   string `json:"volValue"`
+		} `json:"ticker"`
+	}]
+	if err := k.get(ctx, "/api/v1/market/allTickers", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	tickers := make([]Ticker, 0, len(resp.Data.Ticker))
+	for _, item := range resp.Data.Ticker {
+		if item.Last == "" {
+			continue
+		}
+		tickers = append(tickers, Ticker{
+			Symbol:        joinSymbol(item.Symbol),
+			Price:         parseFloat(item.Last),
+			ChangePercent: parseFloat(item.ChangeRate) * 100,
+			QuoteVolume:   parseFloat(item.VolValue),
+		})
+	}
+	return tickers, nil
+}
+
+func (k *KuCoin) Klines(ctx context.Context, query KlinesQuery) ([]Kline, error) {
+	interval, ok := kucoinIntervals[query.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedInterval, query.Interval)
+	}
+	duration, err := intervalDuration(query.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime := query.StartTime, query.EndTime
+	if startTime == 0 && query.Limit > 0 {
+		end := endTime
+		if end == 0 {
+			end = time.Now().UnixMilli()
+		}
+		startTime = end - int64(query.Limit)*duration.Milliseconds()
+	}
+
+	params := url.Values{"symbol": {splitSymbol(query.Symbol)}, "type": {interval}}
+	if startTime > 0 {
+		params.Set("startAt", strconv.FormatInt(startTime/1000, 10))
+	}
+	if endTime > 0 {
+		params.Set("endAt", strconv.FormatInt(endTime/1000, 10))
+	}
+
+	var resp kucoinResponse[[][]string]
+	if err := k.get(ctx, "/api/v1/market/candles", params, &resp); err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(resp.Data))
+	for _, row := range resp.Data {
+		if len(row) < 7 {
+			continue
+		}
+		openTime := parseInt(row[0]) * 1000
+		if openTime < query.StartTime {
+			continue
+		}
+		klines = append(klines, Kline{
+			OpenTime:    openTime,
+			Open:        parseFloat(row[1]),
+			Close:       parseFloat(row[2]),
+			High:        parseFloat(row[3]),
+			Low:         parseFloat(row[4]),
+			Volume:      parseFloat(row[5]),
+			QuoteVolume: parseFloat(row[6]),
+			CloseTime:   openTime + duration.Milliseconds() - 1,
+		})
+	}
+
+	klines = newestLast(klines, 0)
+	if query.Limit > 0 && len(klines) > query.Limit {
+		if query.StartTime > 0 {
+			klines = klines[:query.Limit]
+		} else {
+			klines = klines[len(klines)-query.Limit:]
+		}
+	}
+	return klines, nil
+}
+
+func (k *KuCoin) Symbols(ctx context.Context) ([]Symbol, error) {
+	var resp kucoinResponse[[]struct {
+		Symbol        string `json:"symbol"`
+		BaseCurrency  string `json:"baseCurrency"`
+		QuoteCurrency string `json:"quoteCurrency"`
+		EnableTrading bool   `json:"enableTrading"`
+	}]
+	if err := k.get(ctx, "/api/v2/symbols", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]Symbol, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		symbols = append(symbols, Symbol{
+			Symbol:     joinSymbol(item.Symbol),
+			BaseAsset:  item.BaseCurrency,
+			QuoteAsset: item.QuoteCurrency,
+			Trading:    item.EnableTrading,
+		})
+	}
+	return symbols, nil
+}
+
+func (k *KuCoin) get(ctx context.Context, path string, query url.Values, resp interface{ err() error }) error {
+	if err := getJSON(ctx, k.baseURL, path, query, resp); err != nil {
+		return err
+	}
+	return resp.err()
+}
+
+func (r *kucoinResponse[T]) err() error {
+	if r.Code != "200000" {
+		return fmt.Errorf("kucoin error %s: %s", r.Code, r.Msg)
+	}
+	return nil
+}
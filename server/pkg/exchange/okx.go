@@ -0,0 +1,184 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const OKXBaseURL = "https://www.okx.com"
+
+const okxMaxCandles = 300
+
+var okxIntervals = map[string]string{
+	"1m":  "1m",
+	"3m":  "3m",
+	"5m":  "5m",
+	"15m": "15m",
+	"30m": "30m",
+	"1h":  "1H",
+	"2h":  "2H",
+	"4h":  "4H",
+	"6h":  "6Hutc",
+	"12h": "12Hutc",
+	"1d":  "1Dutc",
+}
+
+// OKX reads the v5 spot market endpoints. OKX has no 24h change field, it is
+// derived from the open price 24 hours ago.
+type OKX struct {
+	baseURL string
+	tickers tickerCache
+}
+
+type okxResponse[T any] struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data T      `json:"data"`
+}
+
+func NewOKX(baseURL string) *OKX {
+	if baseURL == "" {
+		baseURL = OKXBaseURL
+	}
+	return &OKX{baseURL: baseURL}
+}
+
+func (o *OKX) Name() string {
+	return "okx"
+}
+
+func (o *OKX) Prices(ctx context.Context) ([]Price, error) {
+	tickers, err := o.Tickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pricesOf(tickers), nil
+}
+
+func (o *OKX) Tickers(ctx context.Context) ([]Ticker, error) {
+	return o.tickers.get(ctx, o.fetchTickers)
+}
+
+func (o *OKX) fetchTickers(ctx context.Context) ([]Ticker, error) {
+	var resp okxResponse[[]struct {
+		InstID  string `json:"instId"`
+		Last    string `json:"last"`
+		Open24h string `json:"open24h"`
+		VolCcy  string `json:"volCcy24h"`
+	}]
+	if err := o.get(ctx, "/api/v5/market/tickers", url.Values{"instType": {"SPOT"}}, &resp); err != nil {
+		return nil, err
+	}
+
+	tickers := make([]Ticker, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		last := parseFloat(item.Last)
+		open := parseFloat(item.Open24h)
+		change := 0.0
+		if open > 0 {
+			change = (last - open) / open * 100
+		}
+		tickers = append(tickers, Ticker{
+			Symbol:        joinSymbol(item.InstID),
+			Price:         last,
+			ChangePercent: change,
+			QuoteVolume:   parseFloat(item.VolCcy),
+		})
+	}
+	return tickers, nil
+}
+
+func (o *OKX) Klines(ctx context.Context, query KlinesQuery) ([]Kline, error) {
+	bar, ok := okxIntervals[query.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedInterval, query.Interval)
+	}
+	duration, err := intervalDuration(query.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > okxMaxCandles {
+		limit = okxMaxCandles
+	}
+
+	// OKX pages backwards from "after", so a start time alone is turned into
+	// the end of a window of limit candles.
+	endTime := query.EndTime
+	if endTime == 0 && query.StartTime > 0 {
+		endTime = query.StartTime + int64(limit)*duration.Milliseconds()
+	}
+
+	params := url.Values{"instId": {splitSymbol(query.Symbol)}, "bar": {bar}, "limit": {strconv.Itoa(limit)}}
+	if endTime > 0 {
+		params.Set("after", strconv.FormatInt(endTime+1, 10))
+	}
+	if query.StartTime > 0 {
+		params.Set("before", strconv.FormatInt(query.StartTime-1, 10))
+	}
+
+	var resp okxResponse[[][]string]
+	if err := o.get(ctx, "/api/v5/market/candles", params, &resp); err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(resp.Data))
+	for _, row := range resp.Data {
+		if len(row) < 8 {
+			continue
+		}
+		openTime := parseInt(row[0])
+		klines = append(klines, Kline{
+			OpenTime:    openTime,
+			Open:        parseFloat(row[1]),
+			High:        parseFloat(row[2]),
+			Low:         parseFloat(row[3]),
+			Close:       parseFloat(row[4]),
+			Volume:      parseFloat(row[5]),
+			QuoteVolume: parseFloat(row[7]),
+			CloseTime:   openTime + duration.Milliseconds() - 1,
+		})
+	}
+	return newestLast(klines, query.Limit), nil
+}
+
+func (o *OKX) Symbols(ctx context.Context) ([]Symbol, error) {
+	var resp okxResponse[[]struct {
+		InstID   string `json:"instId"`
+		BaseCcy  string `json:"baseCcy"`
+		QuoteCcy string `json:"quoteCcy"`
+		State    string `json:"state"`
+	}]
+	if err := o.get(ctx, "/api/v5/public/instruments", url.Values{"instType": {"SPOT"}}, &resp); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]Symbol, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		symbols = append(symbols, Symbol{
+			Symbol:     joinSymbol(item.InstID),
+			BaseAsset:  item.BaseCcy,
+			QuoteAsset: item.QuoteCcy,
+			Trading:    item.State == "live",
+			Status:     item.State,
+		})
+	}
+	return symbols, nil
+}
+
+func (o *OKX) get(ctx context.Context, path string, query url.Values, resp interface{ err() error }) error {
+	if err := getJSON(ctx, o.baseURL, path, query, resp); err != nil {
+		return err
+	}
+	return resp.err()
+}
+
+func (r *okxResponse[T]) err() error {
+	if r.Code != "0" {
+		return fmt.Errorf("okx error %s: %s", r.Code, r.Msg)
+	}
+	return nil
+}
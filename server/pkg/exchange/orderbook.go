@@ -0,0 +1,75 @@
+package exchange
+
+type Level struct {
+	Price    float64
+	Quantity float64
+}
+
+// OrderBook is a depth snapshot, bids best first (highest price) and asks
+// best first (lowest price). Limit is the number of levels asked for per
+// side, a side with fewer levels is the whole side.
+type OrderBook struct {
+	Symbol       string
+	Bids         []Level
+	Asks         []Level
+	LastUpdateID int64
+	Time         int64
+	Limit        int
+}
+
+// Liquidity sums the quote notional resting within ±BandPercent of the mid
+// price. Imbalance goes from -1 (only asks) to 1 (only bids). Complete is
+// true when the snapshot covers the whole band on both sides, either by
+// reaching past it or by holding the whole side.
+type Liquidity struct {
+	BandPercent   float64
+	MidPrice      float64
+	SpreadPercent float64
+	BidNotional   float64
+	AskNotional   float64
+	Imbalance     float64
+	Complete      bool
+}
+
+// Liquidity is zero apart from the band when either side of the book is
+// empty. Levels beyond the depth that was fetched are not counted, so a
+// shallow snapshot understates a wide band and is not Complete.
+func (b OrderBook) Liquidity(bandPercent float64) Liquidity {
+	liquidity := Liquidity{BandPercent: bandPercent}
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return liquidity
+	}
+
+	bestBid, bestAsk := b.Bids[0].Price, b.Asks[0].Price
+	mid := (bestBid + bestAsk) / 2
+	if mid <= 0 {
+		return liquidity
+	}
+	liquidity.MidPrice = mid
+	liquidity.SpreadPercent = (bestAsk - bestBid) / mid * 100
+
+	low := mid * (1 - bandPercent/100)
+	high := mid * (1 + bandPercent/100)
+	bidsCovered := len(b.Bids) < b.Limit
+	for _, level := range b.Bids {
+		if level.Price < low {
+			bidsCovered = true
+			break
+		}
+		liquidity.BidNotional += level.Price * level.Quantity
+	}
+	asksCovered := len(b.Asks) < b.Limit
+	for _, level := range b.Asks {
+		if level.Price > high {
+			asksCovered = true
+			break
+		}
+		liquidity.AskNotional += level.Price * level.Quantity
+	}
+	liquidity.Complete = bidsCovered && asksCovered
+
+	if total := liquidity.BidNotional + liquidity.AskNotional; total > 0 {
+		liquidity.Imbalance = (liquidity.BidNotional - liquidity.AskNotional) / total
+	}
+	return liquidity
+}
@@ -0,0 +1,49 @@
+package exchange
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"time"
+)
+
+const streamReadTimeout = time.Minute
+
+// stream reads one websocket stream until the context ends or the connection
+// fails and hands every message to handle. Binance pings every 20 seconds, a
+// connection with neither pings nor messages for streamReadTimeout is dead.
+func stream(ctx context.Context, endpoint string, handle func(message []byte) error) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(10*time.Second))
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if err := handle(message); err != nil {
+			return err
+		}
+	}
+}
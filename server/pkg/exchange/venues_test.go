@@ -0,0 +1,240 @@
+package exchange
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// venueCase replays recorded API responses from testdata, keyed by request
+// path, against one venue adapter.
+type venueCase struct {
+	name    string
+	new     func(baseURL string) Exchange
+	routes  map[string]string
+	tickers []Ticker
+	klines  []Kline
+	symbols []Symbol
+	// errorPath answers with the venue's error payload (HTTP 200, error code
+	// in the body).
+	errorPath string
+}
+
+var venueCases = []venueCase{
+	{
+		name: "bybit",
+		new:  func(baseURL string) Exchange { return NewBybit(baseURL) },
+		routes: map[string]string{
+			"/v5/market/tickers":          "bybit_tickers.json",
+			"/v5/market/kline":            "bybit_kline.json",
+			"/v5/market/instruments-info": "bybit_instruments.json",
+		},
+		tickers: []Ticker{
+			{Symbol: "BTCUSDT", Price: 36500, ChangePercent: 1.2, QuoteVolume: 766500000.51},
+			{Symbol: "PEPEUSDT", Price: 0.0000012, ChangePercent: 18.5, QuoteVolume: 10800000},
+		},
+		klines: []Kline{
+			{OpenTime: 1699999920000, Open: 0.0000012, High: 0.00000121, Low: 0.00000119, Close: 0.0000012, Volume: 110000000000, QuoteVolume: 132000, CloseTime: 1699999979999},
+			{OpenTime: 1699999980000, Open: 0.0000012, High: 0.0000013, Low: 0.0000012, Close: 0.0000013, Volume: 400000000000, QuoteVolume: 500000, CloseTime: 1700000039999},
+		},
+		symbols: []Symbol{
+			{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Trading: true, Status: "Trading"},
+			{Symbol: "NEWUSDT", BaseAsset: "NEW", QuoteAsset: "USDT", Trading: false, Status: "PreLaunch"},
+		},
+		errorPath: "bybit_error.json",
+	},
+	{
+		name: "okx",
+		new:  func(baseURL string) Exchange { return NewOKX(baseURL) },
+		routes: map[string]string{
+			"/api/v5/market/tickers":     "okx_tickers.json",
+			"/api/v5/market/candles":     "okx_candles.json",
+			"/api/v5/public/instruments": "okx_instruments.json",
+		},
+		tickers: []Ticker{
+			{Symbol: "BTCUSDT", Price: 36500, ChangePercent: 500.0 / 36000 * 100, QuoteVolume: 766500000},
+			{Symbol: "ETHBTC", Price: 0.0563, ChangePercent: 0, QuoteVolume: 2308},
+		},
+		klines: []Kline{
+			{OpenTime: 1699999920000, Open: 0.0000012, High: 0.00000121, Low: 0.00000119, Close: 0.0000012, Volume: 110000000000, QuoteVolume: 132000, CloseTime: 1699999979999},
+			{OpenTime: 1699999980000, Open: 0.0000012, High: 0.0000013, Low: 0.0000012, Close: 0.0000013, Volume: 400000000000, QuoteVolume: 500000, CloseTime: 1700000039999},
+		},
+		symbols: []Symbol{
+			{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Trading: true, Status: "live"},
+			{Symbol: "NEWUSDT", BaseAsset: "NEW", QuoteAsset: "USDT", Trading: false, Status: "preopen"},
+		},
+		errorPath: "okx_error.json",
+	},
+	{
+		name: "kucoin",
+		new:  func(baseURL string) Exchange { return NewKuCoin(baseURL) },
+		routes: map[string]string{
+			"/api/v1/market/allTickers": "kucoin_tickers.json",
+			"/api/v1/market/candles":    "kucoin_candles.json",
+			"/api/v2/symbols":           "kucoin_symbols.json",
+		},
+		tickers: []Ticker{
+			{Symbol: "BTCUSDT", Price: 36500, ChangePercent: 1.2, QuoteVolume: 766500000},
+		},
+		klines: []Kline{
+			{OpenTime: 1699999920000, Open: 0.0000012, High: 0.00000121, Low: 0.00000119, Close: 0.0000012, Volume: 110000000000, QuoteVolume: 132000, CloseTime: 1699999979999},
+			{OpenTime: 1699999980000, Open: 0.0000012, High: 0.0000013, Low: 0.0000012, Close: 0.0000013, Volume: 400000000000, QuoteVolume: 500000, CloseTime: 1700000039999},
+		},
+		symbols: []Symbol{
+			{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Trading: true},
+			{Symbol: "OLDUSDT", BaseAsset: "OLD", QuoteAsset: "USDT", Trading: false},
+		},
+		errorPath: "kucoin_error.json",
+	},
+}
+
+// serveRecorded answers every route with its testdata file, or every request
+// with one file when routes is nil, and counts the requests.
+func serveRecorded(t *testing.T, routes map[string]string, file string) (string, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		name := file
+		if routes != nil {
+			name = routes[r.URL.Path]
+		}
+		if name == "" {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("reading %s: %v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &requests
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestVenueTickers(t *testing.T) {
+	for _, tt := range venueCases {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := serveRecorded(t, tt.routes, "")
+			tickers, err := tt.new(baseURL).Tickers(context.Background())
+			if err != nil {
+				t.Fatalf("Tickers: %v", err)
+			}
+			if len(tickers) != len(tt.tickers) {
+				t.Fatalf("got %+v, want %+v", tickers, tt.tickers)
+			}
+			for i, want := range tt.tickers {
+				got := tickers[i]
+				if got.Symbol != want.Symbol || !closeTo(got.Price, want.Price) || !closeTo(got.ChangePercent, want.ChangePercent) || !closeTo(got.QuoteVolume, want.QuoteVolume) {
+					t.Errorf("ticker %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestVenueKlines(t *testing.T) {
+	for _, tt := range venueCases {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := serveRecorded(t, tt.routes, "")
+			klines, err := tt.new(baseURL).Klines(context.Background(), KlinesQuery{Symbol: "PEPEUSDT", Interval: "1m", Limit: 2})
+			if err != nil {
+				t.Fatalf("Klines: %v", err)
+			}
+			if len(klines) != len(tt.klines) {
+				t.Fatalf("got %d klines, want %d: %+v", len(klines), len(tt.klines), klines)
+			}
+			for i, want := range tt.klines {
+				if klines[i] != want {
+					t.Errorf("kline %d = %+v, want %+v", i, klines[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestVenueSymbols(t *testing.T) {
+	for _, tt := range venueCases {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := serveRecorded(t, tt.routes, "")
+			symbols, err := tt.new(baseURL).Symbols(context.Background())
+			if err != nil {
+				t.Fatalf("Symbols: %v", err)
+			}
+			if len(symbols) != len(tt.symbols) {
+				t.Fatalf("got %+v, want %+v", symbols, tt.symbols)
+			}
+			for i, want := range tt.symbols {
+				if symbols[i] != want {
+					t.Errorf("symbol %d = %+v, want %+v", i, symbols[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestVenueErrors(t *testing.T) {
+	for _, tt := range venueCases {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := serveRecorded(t, nil, tt.errorPath)
+			if _, err := tt.new(baseURL).Tickers(context.Background()); err == nil {
+				t.Error("Tickers returned no error for an error payload")
+			}
+			if _, err := tt.new(baseURL).Klines(context.Background(), KlinesQuery{Symbol: "PEPEUSDT", Interval: "1m", Limit: 2}); err == nil {
+				t.Error("Klines returned no error for an error payload")
+			}
+			if _, err := tt.new(baseURL).Klines(context.Background(), KlinesQuery{Symbol: "PEPEUSDT", Interval: "2m"}); err == nil {
+				t.Error("Klines accepted an unsupported interval")
+			}
+		})
+	}
+}
+
+func TestVenuePricesReuseTickers(t *testing.T) {
+	for _, tt := range venueCases {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, requests := serveRecorded(t, tt.routes, "")
+			venue := tt.new(baseURL)
+			if _, err := venue.Tickers(context.Background()); err != nil {
+				t.Fatalf("Tickers: %v", err)
+			}
+			prices, err := venue.Prices(context.Background())
+			if err != nil {
+				t.Fatalf("Prices: %v", err)
+			}
+			if len(prices) != len(tt.tickers) || prices[0].Symbol != tt.tickers[0].Symbol || prices[0].Price != tt.tickers[0].Price {
+				t.Errorf("got %+v from tickers %+v", prices, tt.tickers)
+			}
+			if got := atomic.LoadInt32(requests); got != 1 {
+				t.Errorf("made %d requests, want one for both calls", got)
+			}
+		})
+	}
+}
+
+func TestVenueIntervals(t *testing.T) {
+	venues := map[string]map[string]string{"bybit": bybitIntervals, "okx": okxIntervals, "kucoin": kucoinIntervals}
+	for name, names := range venues {
+		for interval := range intervals {
+			if _, ok := names[interval]; !ok {
+				t.Errorf("%s has no name for the %s interval", name, interval)
+			}
+		}
+		if len(names) != len(intervals) {
+			t.Errorf("%s knows %d intervals, the server %d", name, len(names), len(intervals))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package fakebinance
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultDepth is the USDT notional on each side within ±2% of the price
+	// when the ticker does not set bid_depth or ask_depth.
+	defaultDepth = 100000
+	depthStep    = 0.001
+	depthLevels  = 20
+)
+
+type depthResponse struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+func (s *Server) handleDepth(w http.ResponseWriter, r *http.Request) {
+	frame := s.current()
+	ticker, ok := findTicker(frame, r.URL.Query().Get("symbol"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]interface{}{"code": -1121, "msg": "Invalid symbol."})
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 100
+	}
+	writeJSON(w, depthSnapshot(ticker, limit, s.now(frame)))
+}
+
+// depthSnapshot builds a symmetric book around the ticker price, one level
+// every 0.1%, with the configured depth spread evenly over the first 20
+// levels (±2%) and the same size per level beyond them.
+func depthSnapshot(ticker Ticker, levels int, now int64) depthResponse {
+	bidDepth, askDepth := ticker.BidDepth, ticker.AskDepth
+	if bidDepth == 0 {
+		bidDepth = defaultDepth
+	}
+	if askDepth == 0 {
+		askDepth = defaultDepth
+	}
+
+	book := depthResponse{
+		LastUpdateID: now,
+		Bids:         make([][2]string, 0, levels),
+		Asks:         make([][2]string, 0, levels),
+	}
+	for i := 0; i < levels; i++ {
+		bid := ticker.Price * (1 - depthStep*float64(i+1))
+		ask := ticker.Price * (1 + depthStep*float64(i))
+		book.Bids = append(book.Bids, [2]string{formatFloat(bid), formatFloat(bidDepth / depthLevels / bid)})
+		book.Asks = append(book.Asks, [2]string{formatFloat(ask), formatFloat(askDepth / depthLevels / ask)})
+	}
+	return book
+}
+
+// parseDepthStream reads <symbol>@depth<levels> with an optional @100ms.
+func parseDepthStream(stream string) (string, int) {
+	symbol, rest, _ := strings.Cut(stream, "@depth")
+	rest, _, _ = strings.Cut(rest, "@")
+	levels, err := strconv.Atoi(rest)
+	if err != nil || levels <= 0 {
+		levels = depthLevels
+	}
+	return symbol, levels
+}
@@ -0,0 +1,71 @@
+package fakebinance
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+var ErrEmptyScript = errors.New("script has no frames")
+
+// Script is the fixture the fake exchange serves. Every frame is one state of
+// the market; the server starts at the first frame and moves forward when
+// advanced. Klines are served as they are, filtered by the request.
+type Script struct {
+	Frames []Frame            `json:"frames"`
+	Klines map[string][]Kline `json:"klines"`
+}
+
+type Frame struct {
+	Time    int64    `json:"time"`
+	Tickers []Ticker `json:"tickers"`
+	Trades  []Trade  `json:"trades"`
+}
+
+// Trade is sent on the aggTrade stream of its symbol when its frame is
+// reached, and to streams opened while the frame is current.
+type Trade struct {
+	Symbol     string  `json:"symbol"`
+	Price      float64 `json:"price"`
+	Quantity   float64 `json:"quantity"`
+	BuyerMaker bool    `json:"buyer_maker"`
+}
+
+type Ticker struct {
+	Symbol        string  `json:"symbol"`
+	Price         float64 `json:"price"`
+	ChangePercent float64 `json:"change_percent"`
+	Volume        float64 `json:"volume"`
+	QuoteVolume   float64 `json:"quote_volume"`
+	BidDepth      float64 `json:"bid_depth"`
+	AskDepth      float64 `json:"ask_depth"`
+	// Status is served in exchangeInfo, TRADING when empty.
+	Status string `json:"status"`
+}
+
+type Kline struct {
+	OpenTime    int64   `json:"open_time"`
+	Open        float64 `json:"open"`
+	High        float64 `json:"high"`
+	Low         float64 `json:"low"`
+	Close       float64 `json:"close"`
+	Volume      float64 `json:"volume"`
+	QuoteVolume float64 `json:"quote_volume"`
+	CloseTime   int64   `json:"close_time"`
+}
+
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var script Script
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, err
+	}
+	if len(script.Frames) == 0 {
+		return nil, ErrEmptyScript
+	}
+	return &script, nil
+}
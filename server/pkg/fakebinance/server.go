@@ -0,0 +1,525 @@
+package fakebinance
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/adshao/go-binance/v2"
+	"github.com/gorilla/websocket"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Server struct {
+	mu          sync.Mutex
+	script      *Script
+	frame       int
+	listener    net.Listener
+	httpServer  *http.Server
+	subscribers map[*subscriber]bool
+	upgrader    websocket.Upgrader
+}
+
+type subscriber struct {
+	conn     *websocket.Conn
+	streams  []string
+	combined bool
+	mu       sync.Mutex
+}
+
+func New(script *Script) (*Server, error) {
+	if script == nil || len(script.Frames) == 0 {
+		return nil, ErrEmptyScript
+	}
+	return &Server{
+		script:      script,
+		subscribers: make(map[*subscriber]bool),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+	}, nil
+}
+
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: s.Handler()}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Fake Binance server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	for sub := range s.subscribers {
+		_ = sub.conn.Close()
+	}
+	s.mu.Unlock()
+
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Close()
+}
+
+// URL is the REST base URL to pass to the Binance client.
+func (s *Server) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// StreamURL is the websocket base, streams are served at /ws/<stream> and
+// /stream?streams=<stream>/<stream>.
+func (s *Server) StreamURL() string {
+	return "ws://" + s.listener.Addr().String()
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/ping", s.handlePing)
+	mux.HandleFunc("/api/v3/time", s.handleTime)
+	mux.HandleFunc("/api/v3/ticker/price", s.handlePrices)
+	mux.HandleFunc("/api/v3/ticker/24hr", s.handleTickers)
+	mux.HandleFunc("/api/v3/exchangeInfo", s.handleExchangeInfo)
+	mux.HandleFunc("/api/v3/klines", s.handleKlines)
+	mux.HandleFunc("/api/v3/depth", s.handleDepth)
+	mux.HandleFunc("/ws/", s.handleStream)
+	mux.HandleFunc("/stream", s.handleStream)
+	mux.HandleFunc("/fake/advance", s.handleAdvance)
+	mux.HandleFunc("/fake/frame", s.handleFrame)
+	return mux
+}
+
+func (s *Server) Frame() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.frame
+}
+
+func (s *Server) Advance() bool {
+	s.mu.Lock()
+	if s.frame+1 >= len(s.script.Frames) {
+		s.mu.Unlock()
+		return false
+	}
+	s.frame++
+	s.mu.Unlock()
+
+	s.broadcast()
+	return true
+}
+
+func (s *Server) SetFrame(index int) error {
+	s.mu.Lock()
+	if index < 0 || index >= len(s.script.Frames) {
+		s.mu.Unlock()
+		return fmt.Errorf("frame %d is out of range 0..%d", index, len(s.script.Frames)-1)
+	}
+	s.frame = index
+	s.mu.Unlock()
+
+	s.broadcast()
+	return nil
+}
+
+func (s *Server) current() Frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.script.Frames[s.frame]
+}
+
+func (s *Server) now(frame Frame) int64 {
+	if frame.Time > 0 {
+		return frame.Time
+	}
+	return time.Now().UnixMilli()
+}
+
+func (s *Server) handlePing(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, struct{}{})
+}
+
+func (s *Server) handleTime(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]int64{"serverTime": s.now(s.current())})
+}
+
+func (s *Server) handlePrices(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	prices := make([]binance.SymbolPrice, 0)
+	for _, ticker := range s.current().Tickers {
+		if symbol != "" && ticker.Symbol != symbol {
+			continue
+		}
+		prices = append(prices, binance.SymbolPrice{Symbol: ticker.Symbol, Price: formatFloat(ticker.Price)})
+	}
+	writeSymbolResponse(w, symbol, prices)
+}
+
+func (s *Server) handleTickers(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	frame := s.current()
+	stats := make([]binance.PriceChangeStats, 0)
+	for _, ticker := range frame.Tickers {
+		if symbol != "" && ticker.Symbol != symbol {
+			continue
+		}
+		stats = append(stats, priceChangeStats(ticker, s.now(frame)))
+	}
+	writeSymbolResponse(w, symbol, stats)
+}
+
+func (s *Server) handleExchangeInfo(w http.ResponseWriter, _ *http.Request) {
+	frame := s.current()
+	info := binance.ExchangeInfo{
+		Timezone:   "UTC",
+		ServerTime: s.now(frame),
+		Symbols:    make([]binance.Symbol, 0, len(frame.Tickers)),
+	}
+	for _, ticker := range frame.Tickers {
+		base, quote := splitSymbol(ticker.Symbol)
+		status := ticker.Status
+		if status == "" {
+			status = "TRADING"
+		}
+		info.Symbols = append(info.Symbols, binance.Symbol{
+			Symbol:               ticker.Symbol,
+			Status:               status,
+			BaseAsset:            base,
+			QuoteAsset:           quote,
+			IsSpotTradingAllowed: true,
+			Permissions:          []string{"SPOT"},
+		})
+	}
+	writeJSON(w, info)
+}
+
+func (s *Server) handleKlines(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+	endTime, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit <= 0 {
+		limit = 500
+	}
+
+	var klines []Kline
+	for _, kline := range s.script.Klines[query.Get("symbol")] {
+		if startTime > 0 && kline.OpenTime < startTime {
+			continue
+		}
+		if endTime > 0 && kline.OpenTime > endTime {
+			continue
+		}
+		klines = append(klines, kline)
+	}
+	sort.Slice(klines, func(i, j int) bool {
+		return klines[i].OpenTime < klines[j].OpenTime
+	})
+	if len(klines) > limit {
+		if startTime > 0 {
+			klines = klines[:limit]
+		} else {
+			klines = klines[len(klines)-limit:]
+		}
+	}
+
+	rows := make([][]interface{}, 0, len(klines))
+	for _, kline := range klines {
+		rows = append(rows, []interface{}{
+			kline.OpenTime,
+			formatFloat(kline.Open),
+			formatFloat(kline.High),
+			formatFloat(kline.Low),
+			formatFloat(kline.Close),
+			formatFloat(kline.Volume),
+			kline.CloseTime,
+			formatFloat(kline.QuoteVolume),
+			0,
+			"0",
+			"0",
+			"0",
+		})
+	}
+	writeJSON(w, rows)
+}
+
+func (s *Server) handleAdvance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	s.Advance()
+	writeJSON(w, map[string]int{"frame": s.Frame()})
+}
+
+func (s *Server) handleFrame(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		index, err := strconv.Atoi(r.URL.Query().Get("index"))
+		if err == nil {
+			err = s.SetFrame(index)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	writeJSON(w, map[string]int{"frame": s.Frame()})
+}
+
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	sub := &subscriber{}
+	if strings.HasPrefix(r.URL.Path, "/ws/") {
+		sub.streams = strings.Split(strings.TrimPrefix(r.URL.Path, "/ws/"), "/")
+	} else {
+		sub.streams = strings.Split(r.URL.Query().Get("streams"), "/")
+		sub.combined = true
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Fake Binance websocket upgrade failed: %v", err)
+		return
+	}
+	sub.conn = conn
+
+	s.mu.Lock()
+	s.subscribers[sub] = true
+	s.mu.Unlock()
+	s.send(sub, s.current())
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+	_ = conn.Close()
+}
+
+func (s *Server) broadcast() {
+	frame := s.current()
+	s.mu.Lock()
+	subscribers := make([]*subscriber, 0, len(s.subscribers))
+	for sub := range s.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	s.mu.Unlock()
+
+	for _, sub := range subscribers {
+		s.send(sub, frame)
+	}
+}
+
+func (s *Server) send(sub *subscriber, frame Frame) {
+	for _, stream := range sub.streams {
+		var events []interface{}
+		if symbol, ok := strings.CutSuffix(stream, "@aggTrade"); ok {
+			events = aggTradeEvents(frame, symbol, s.now(frame))
+		} else if data, ok := s.streamEvent(stream, frame); ok {
+			events = append(events, data)
+		}
+
+		for _, data := range events {
+			var message interface{} = data
+			if sub.combined {
+				message = map[string]interface{}{"stream": stream, "data": data}
+			}
+
+			sub.mu.Lock()
+			err := sub.conn.WriteJSON(message)
+			sub.mu.Unlock()
+			if err != nil {
+				log.Printf("Fake Binance websocket write failed: %v", err)
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) streamEvent(stream string, frame Frame) (interface{}, bool) {
+	now := s.now(frame)
+	switch {
+	case stream == "!miniTicker@arr":
+		events := make([]binance.WsMiniMarketsStatEvent, 0, len(frame.Tickers))
+		for _, ticker := range frame.Tickers {
+			events = append(events, miniTickerEvent(ticker, now))
+		}
+		return events, true
+	case stream == "!ticker@arr":
+		events := make([]binance.WsMarketStatEvent, 0, len(frame.Tickers))
+		for _, ticker := range frame.Tickers {
+			events = append(events, tickerEvent(ticker, now))
+		}
+		return events, true
+	case strings.HasSuffix(stream, "@miniTicker"):
+		ticker, ok := findTicker(frame, strings.TrimSuffix(stream, "@miniTicker"))
+		return miniTickerEvent(ticker, now), ok
+	case strings.HasSuffix(stream, "@ticker"):
+		ticker, ok := findTicker(frame, strings.TrimSuffix(stream, "@ticker"))
+		return tickerEvent(ticker, now), ok
+	case strings.Contains(stream, "@depth"):
+		symbol, levels := parseDepthStream(stream)
+		ticker, ok := findTicker(frame, symbol)
+		return depthSnapshot(ticker, levels, now), ok
+	}
+	return nil, false
+}
+
+func aggTradeEvents(frame Frame, symbol string, now int64) []interface{} {
+	var events []interface{}
+	for i, trade := range frame.Trades {
+		if !strings.EqualFold(trade.Symbol, symbol) {
+			continue
+		}
+		events = append(events, binance.WsAggTradeEvent{
+			Event:        "aggTrade",
+			Time:         now,
+			Symbol:       trade.Symbol,
+			AggTradeID:   now*1000 + int64(i),
+			Price:        formatFloat(trade.Price),
+			Quantity:     formatFloat(trade.Quantity),
+			TradeTime:    now,
+			IsBuyerMaker: trade.BuyerMaker,
+		})
+	}
+	return events
+}
+
+func findTicker(frame Frame, symbol string) (Ticker, bool) {
+	for _, ticker := range frame.Tickers {
+		if strings.EqualFold(ticker.Symbol, symbol) {
+			return ticker, true
+		}
+	}
+	return Ticker{}, false
+}
+
+func priceChangeStats(ticker Ticker, now int64) binance.PriceChangeStats {
+	open := openPrice(ticker)
+	return binance.PriceChangeStats{
+		Symbol:             ticker.Symbol,
+		PriceChange:        formatFloat(ticker.Price - open),
+		PriceChangePercent: formatFloat(ticker.ChangePercent),
+		WeightedAvgPrice:   formatFloat(ticker.Price),
+		PrevClosePrice:     formatFloat(open),
+		LastPrice:          formatFloat(ticker.Price),
+		OpenPrice:          formatFloat(open),
+		HighPrice:          formatFloat(maxFloat(open, ticker.Price)),
+		LowPrice:           formatFloat(minFloat(open, ticker.Price)),
+		Volume:             formatFloat(ticker.Volume),
+		QuoteVolume:        formatFloat(ticker.QuoteVolume),
+		OpenTime:           now - int64(24*time.Hour/time.Millisecond),
+		CloseTime:          now,
+	}
+}
+
+func tickerEvent(ticker Ticker, now int64) binance.WsMarketStatEvent {
+	stats := priceChangeStats(ticker, now)
+	return binance.WsMarketStatEvent{
+		Event:              "24hrTicker",
+		Time:               now,
+		Symbol:             stats.Symbol,
+		PriceChange:        stats.PriceChange,
+		PriceChangePercent: stats.PriceChangePercent,
+		WeightedAvgPrice:   stats.WeightedAvgPrice,
+		PrevClosePrice:     stats.PrevClosePrice,
+		LastPrice:          stats.LastPrice,
+		OpenPrice:          stats.OpenPrice,
+		HighPrice:          stats.HighPrice,
+		LowPrice:           stats.LowPrice,
+		BaseVolume:         stats.Volume,
+		QuoteVolume:        stats.QuoteVolume,
+		OpenTime:           stats.OpenTime,
+		CloseTime:          stats.CloseTime,
+	}
+}
+
+func miniTickerEvent(ticker Ticker, now int64) binance.WsMiniMarketsStatEvent {
+	open := openPrice(ticker)
+	return binance.WsMiniMarketsStatEvent{
+		Event:       "24hrMiniTicker",
+		Time:        now,
+		Symbol:      ticker.Symbol,
+		LastPrice:   formatFloat(ticker.Price),
+		OpenPrice:   formatFloat(open),
+		HighPrice:   formatFloat(maxFloat(open, ticker.Price)),
+		LowPrice:    formatFloat(minFloat(open, ticker.Price)),
+		BaseVolume:  formatFloat(ticker.Volume),
+		QuoteVolume: formatFloat(ticker.QuoteVolume),
+	}
+}
+
+func openPrice(ticker Ticker) float64 {
+	return ticker.Price / (1 + ticker.ChangePercent/100)
+}
+
+func splitSymbol(symbol string) (string, string) {
+	for _, quote := range []string{"USDT", "BUSD", "USDC", "BTC", "ETH", "BNB"} {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote), quote
+		}
+	}
+	return symbol, ""
+}
+
+func writeSymbolResponse(w http.ResponseWriter, symbol string, list interface{}) {
+	if symbol == "" {
+		writeJSON(w, list)
+		return
+	}
+
+	switch items := list.(type) {
+	case []binance.SymbolPrice:
+		if len(items) == 1 {
+			writeJSON(w, items[0])
+			return
+		}
+	case []binance.PriceChangeStats:
+		if len(items) == 1 {
+			writeJSON(w, items[0])
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Fake Binance response failed: %v", err)
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
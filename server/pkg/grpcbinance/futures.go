@@ -0,0 +1,273 @@
+package grpcbinance
+
+import (
+	"context"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	maxOpenInterestSymbols = 100
+	openInterestWorkers    = 8
+	maxOpenInterestHistory = 500
+	maxFundingRates        = 1000
+)
+
+var openInterestPeriods = map[string]bool{
+	"5m": true, "15m": true, "30m": true, "1h": true, "2h": true, "4h": true, "6h": true, "12h": true, "1d": true,
+}
+
+// FuturesServiceServer serves Binance USDⓈ-M perpetual futures market data.
+type FuturesServiceServer struct {
+	proto.UnimplementedFuturesServiceServer
+	client *futures.Client
+}
+
+func NewFuturesServiceServer(apiKey, secretKey string) *FuturesServiceServer {
+	return &FuturesServiceServer{
+		client: futures.NewClient(apiKey, secretKey),
+	}
+}
+
+func (s *FuturesServiceServer) SetBaseURL(baseURL string) {
+	s.client.BaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+func (s *FuturesServiceServer) GetFuturesPrices(ctx context.Context, _ *proto.Empty) (*proto.USDTPricesResponse, error) {
+	prices, err := s.client.NewListPricesService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usdtPrices := make([]*proto.USDTPrice, 0, len(prices))
+	for _, price := range prices {
+		if !strings.HasSuffix(price.Symbol, "USDT") {
+			continue
+		}
+		priceFloat, _ := strconv.ParseFloat(price.Price, 64)
+		usdtPrices = append(usdtPrices, &proto.USDTPrice{
+			Symbol: price.Symbol,
+			Price:  priceFloat,
+		})
+	}
+	return &proto.USDTPricesResponse{Prices: usdtPrices}, nil
+}
+
+func (s *FuturesServiceServer) GetFutures24HChangePercent(ctx context.Context, _ *proto.Empty) (*proto.ChangePercentResponse, error) {
+	stats, err := s.client.NewListPriceChangeStatsService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changePercents := make([]*proto.ChangePercent, 0, len(stats))
+	for _, stat := range stats {
+		change, err := strconv.ParseFloat(stat.PriceChangePercent, 64)
+		if err != nil {
+			continue
+		}
+		quoteVolume, _ := strconv.ParseFloat(stat.QuoteVolume, 64)
+		changePercents = append(changePercents, &proto.ChangePercent{
+			Symbol:        stat.Symbol,
+			ChangePercent: change,
+			QuoteVolume:   quoteVolume,
+		})
+	}
+	return &proto.ChangePercentResponse{ChangePercents: changePercents}, nil
+}
+
+func (s *FuturesServiceServer) GetFuturesKlines(ctx context.Context, req *proto.KlinesRequest) (*proto.KlinesResponse, error) {
+	interval := req.Interval
+	if interval == "" {
+		interval = "1m"
+	}
+
+	service := s.client.NewKlinesService().Symbol(req.Symbol).Interval(interval)
+	if req.StartTime > 0 {
+		service = service.StartTime(req.StartTime)
+	}
+	if req.EndTime > 0 {
+		service = service.EndTime(req.EndTime)
+	}
+	if req.Limit > 0 {
+		service = service.Limit(int(req.Limit))
+	}
+
+	klines, err := service.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protoKlines := make([]*proto.Kline, 0, len(klines))
+	for _, kline := range klines {
+		open, _ := strconv.ParseFloat(kline.Open, 64)
+		high, _ := strconv.ParseFloat(kline.High, 64)
+		low, _ := strconv.ParseFloat(kline.Low, 64)
+		closePrice, _ := strconv.ParseFloat(kline.Close, 64)
+		volume, _ := strconv.ParseFloat(kline.Volume, 64)
+		quoteVolume, _ := strconv.ParseFloat(kline.QuoteAssetVolume, 64)
+		protoKlines = append(protoKlines, &proto.Kline{
+			OpenTime:    kline.OpenTime,
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       closePrice,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+			CloseTime:   kline.CloseTime,
+		})
+	}
+	return &proto.KlinesResponse{Klines: protoKlines}, nil
+}
+
+func (s *FuturesServiceServer) GetMarkPrices(ctx context.Context, _ *proto.Empty) (*proto.MarkPricesResponse, error) {
+	indexes, err := s.client.NewPremiumIndexService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	markPrices := make([]*proto.MarkPrice, 0, len(indexes))
+	for _, index := range indexes {
+		markPrice, _ := strconv.ParseFloat(index.MarkPrice, 64)
+		fundingRate, _ := strconv.ParseFloat(index.LastFundingRate, 64)
+		markPrices = append(markPrices, &proto.MarkPrice{
+			Symbol:          index.Symbol,
+			MarkPrice:       markPrice,
+			FundingRate:     fundingRate,
+			NextFundingTime: index.NextFundingTime,
+		})
+	}
+	return &proto.MarkPricesResponse{MarkPrices: markPrices}, nil
+}
+
+// GetOpenInterest asks Binance symbol by symbol, it has no bulk endpoint, so
+// the number of symbols per request is capped.
+func (s *FuturesServiceServer) GetOpenInterest(ctx context.Context, req *proto.OpenInterestRequest) (*proto.OpenInterestResponse, error) {
+	if len(req.Symbols) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one symbol is required")
+	}
+	if len(req.Symbols) > maxOpenInterestSymbols {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d symbols per request", maxOpenInterestSymbols)
+	}
+
+	markPrices, err := s.GetMarkPrices(ctx, &proto.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]float64, len(markPrices.MarkPrices))
+	for _, markPrice := range markPrices.MarkPrices {
+		prices[markPrice.Symbol] = markPrice.MarkPrice
+	}
+
+	var (
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+		openInterests = make([]*proto.OpenInterest, 0, len(req.Symbols))
+		symbols       = make(chan string)
+	)
+	for i := 0; i < openInterestWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range symbols {
+				openInterest, err := s.client.NewGetOpenInterestService().Symbol(symbol).Do(ctx)
+				if err != nil {
+					log.Printf("Error getting open interest for %s: %v", symbol, err)
+					continue
+				}
+
+				contracts, _ := strconv.ParseFloat(openInterest.OpenInterest, 64)
+				mu.Lock()
+				openInterests = append(openInterests, &proto.OpenInterest{
+					Symbol:            openInterest.Symbol,
+					OpenInterest:      contracts,
+					OpenInterestValue: contracts * prices[openInterest.Symbol],
+					Time:              openInterest.Time,
+				})
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, symbol := range req.Symbols {
+		symbols <- symbol
+	}
+	close(symbols)
+	wg.Wait()
+
+	return &proto.OpenInterestResponse{OpenInterests: openInterests}, nil
+}
+
+// GetOpenInterestHistory returns the open interest of one symbol sampled every
+// period, oldest first. Binance keeps only the last 30 days of it.
+func (s *FuturesServiceServer) GetOpenInterestHistory(ctx context.Context, req *proto.OpenInterestHistoryRequest) (*proto.OpenInterestResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	period := req.Period
+	if period == "" {
+		period = "5m"
+	}
+	if !openInterestPeriods[period] {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported period %q", period)
+	}
+	if req.Limit < 0 || req.Limit > maxOpenInterestHistory {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxOpenInterestHistory)
+	}
+
+	service := s.client.NewOpenInterestStatisticsService().Symbol(req.Symbol).Period(period)
+	if req.Limit > 0 {
+		service = service.Limit(int(req.Limit))
+	}
+	stats, err := service.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	openInterests := make([]*proto.OpenInterest, 0, len(stats))
+	for _, stat := range stats {
+		contracts, _ := strconv.ParseFloat(stat.SumOpenInterest, 64)
+		value, _ := strconv.ParseFloat(stat.SumOpenInterestValue, 64)
+		openInterests = append(openInterests, &proto.OpenInterest{
+			Symbol:            stat.Symbol,
+			OpenInterest:      contracts,
+			OpenInterestValue: value,
+			Time:              stat.Timestamp,
+		})
+	}
+	return &proto.OpenInterestResponse{OpenInterests: openInterests}, nil
+}
+
+// GetFundingRates returns the settled funding rates of a symbol, oldest first.
+func (s *FuturesServiceServer) GetFundingRates(ctx context.Context, req *proto.FundingRatesRequest) (*proto.FundingRatesResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	if req.Limit < 0 || req.Limit > maxFundingRates {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxFundingRates)
+	}
+
+	service := s.client.NewFundingRateService().Symbol(req.Symbol)
+	if req.Limit > 0 {
+		service = service.Limit(int(req.Limit))
+	}
+	rates, err := service.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingRates := make([]*proto.FundingRate, 0, len(rates))
+	for _, rate := range rates {
+		fundingRate, _ := strconv.ParseFloat(rate.FundingRate, 64)
+		fundingRates = append(fundingRates, &proto.FundingRate{
+			Symbol:      rate.Symbol,
+			FundingRate: fundingRate,
+			FundingTime: rate.FundingTime,
+		})
+	}
+	return &proto.FundingRatesResponse{FundingRates: fundingRates}, nil
+}
@@ -0,0 +1,52 @@
+package grpcbinance
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"github.com/agopankov/imPulse/server/pkg/listings"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"time"
+)
+
+// ListingSeqHeader is the stream header with the seq of the last listing
+// event when the stream opened.
+const ListingSeqHeader = "listing-seq"
+
+// WatchListings starts polling Binance exchangeInfo for listing changes. It
+// is started with the default interval by the first listing stream otherwise.
+func (s *BinanceServiceServer) WatchListings(ctx context.Context, interval time.Duration) {
+	s.listings.Start(ctx, interval)
+}
+
+func (s *BinanceServiceServer) StreamListingEvents(req *proto.ListingEventsRequest, stream proto.BinanceService_StreamListingEventsServer) error {
+	s.listings.Start(context.Background(), listings.DefaultInterval)
+
+	events, latest, cancel := s.listings.Subscribe(req.AfterSeq)
+	defer cancel()
+
+	if err := stream.SendHeader(metadata.Pairs(ListingSeqHeader, strconv.FormatInt(latest, 10))); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			err := stream.Send(&proto.ListingEvent{
+				Symbol:         event.Symbol,
+				Kind:           event.Kind,
+				BaseAsset:      event.BaseAsset,
+				QuoteAsset:     event.QuoteAsset,
+				Status:         event.Status,
+				PreviousStatus: event.PreviousStatus,
+				Time:           event.Time.UnixMilli(),
+				Seq:            event.Seq,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package grpcbinance
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultBookLimit   = 100
+	defaultStreamLimit = 20
+	defaultBandPercent = 2
+)
+
+var (
+	bookLimits   = map[int32]bool{5: true, 10: true, 20: true, 50: true, 100: true, 500: true, 1000: true, 5000: true}
+	streamLimits = map[int32]bool{5: true, 10: true, 20: true}
+	// bandLimits are the depths tried in turn when no limit is asked for,
+	// until one covers the band.
+	bandLimits = []int{defaultBookLimit, 1000, 5000}
+)
+
+func (s *BinanceServiceServer) GetOrderBook(ctx context.Context, req *proto.OrderBookRequest) (*proto.OrderBook, error) {
+	limit, band, err := orderBookParams(req, defaultBookLimit, bookLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := []int{int(limit)}
+	if req.Limit == 0 {
+		limits = bandLimits
+	}
+
+	var book exchange.OrderBook
+	for _, limit := range limits {
+		book, err = s.binance.OrderBook(ctx, req.Symbol, limit)
+		if err != nil {
+			return nil, err
+		}
+		if book.Liquidity(band).Complete {
+			break
+		}
+	}
+	return protoOrderBook(book, band), nil
+}
+
+// StreamOrderBook sends the top of the book about once a second until the
+// client goes away. Only 5, 10 or 20 levels can be streamed.
+func (s *BinanceServiceServer) StreamOrderBook(req *proto.OrderBookRequest, stream proto.BinanceService_StreamOrderBookServer) error {
+	limit, band, err := orderBookParams(req, defaultStreamLimit, streamLimits)
+	if err != nil {
+		return err
+	}
+
+	err = s.binance.StreamOrderBook(stream.Context(), req.Symbol, int(limit), func(book exchange.OrderBook) error {
+		return stream.Send(protoOrderBook(book, band))
+	})
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return nil
+}
+
+func orderBookParams(req *proto.OrderBookRequest, defaultLimit int32, limits map[int32]bool) (int32, float64, error) {
+	if req.Symbol == "" {
+		return 0, 0, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if !limits[limit] {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "unsupported limit %d", limit)
+	}
+
+	band := req.BandPercent
+	if band == 0 {
+		band = defaultBandPercent
+	}
+	if band < 0 || band >= 100 {
+		return 0, 0, status.Error(codes.InvalidArgument, "band_percent must be between 0 and 100")
+	}
+	return limit, band, nil
+}
+
+func protoOrderBook(book exchange.OrderBook, band float64) *proto.OrderBook {
+	liquidity := book.Liquidity(band)
+	return &proto.OrderBook{
+		Symbol:       book.Symbol,
+		Bids:         protoLevels(book.Bids),
+		Asks:         protoLevels(book.Asks),
+		LastUpdateId: book.LastUpdateID,
+		Time:         book.Time,
+		Liquidity: &proto.Liquidity{
+			BandPercent:   liquidity.BandPercent,
+			MidPrice:      liquidity.MidPrice,
+			SpreadPercent: liquidity.SpreadPercent,
+			BidNotional:   liquidity.BidNotional,
+			AskNotional:   liquidity.AskNotional,
+			Imbalance:     liquidity.Imbalance,
+			Complete:      liquidity.Complete,
+		},
+	}
+}
+
+func protoLevels(levels []exchange.Level) []*proto.OrderBookLevel {
+	protoLevels := make([]*proto.OrderBookLevel, 0, len(levels))
+	for _, level := range levels {
+		protoLevels = append(protoLevels, &proto.OrderBookLevel{Price: level.Price, Quantity: level.Quantity})
+	}
+	return protoLevels
+}
@@ -21,14 +21,30 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	BinanceService_GetUSDTPrices_FullMethodName       = "/binance.BinanceService/GetUSDTPrices"
 	BinanceService_Get24HChangePercent_FullMethodName = "/binance.BinanceService/Get24hChangePercent"
+	BinanceService_GetKlines_FullMethodName           = "/binance.BinanceService/GetKlines"
+	BinanceService_GetSymbols_FullMethodName          = "/binance.BinanceService/GetSymbols"
+	BinanceService_GetExchanges_FullMethodName        = "/binance.BinanceService/GetExchanges"
+	BinanceService_GetOrderBook_FullMethodName        = "/binance.BinanceService/GetOrderBook"
+	BinanceService_StreamOrderBook_FullMethodName     = "/binance.BinanceService/StreamOrderBook"
+	BinanceService_SetInterest_FullMethodName         = "/binance.BinanceService/SetInterest"
+	BinanceService_StreamWhaleTrades_FullMethodName   = "/binance.BinanceService/StreamWhaleTrades"
+	BinanceService_StreamListingEvents_FullMethodName = "/binance.BinanceService/StreamListingEvents"
 )
 
 // BinanceServiceClient is the client API for BinanceService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BinanceServiceClient interface {
-	GetUSDTPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*USDTPricesResponse, error)
-	Get24HChangePercent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangePercentResponse, error)
+	GetUSDTPrices(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*USDTPricesResponse, error)
+	Get24HChangePercent(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*ChangePercentResponse, error)
+	GetKlines(ctx context.Context, in *KlinesRequest, opts ...grpc.CallOption) (*KlinesResponse, error)
+	GetSymbols(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*SymbolsResponse, error)
+	GetExchanges(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExchangesResponse, error)
+	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	StreamOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (BinanceService_StreamOrderBookClient, error)
+	SetInterest(ctx context.Context, in *InterestRequest, opts ...grpc.CallOption) (*Empty, error)
+	StreamWhaleTrades(ctx context.Context, in *WhaleTradesRequest, opts ...grpc.CallOption) (BinanceService_StreamWhaleTradesClient, error)
+	StreamListingEvents(ctx context.Context, in *ListingEventsRequest, opts ...grpc.CallOption) (BinanceService_StreamListingEventsClient, error)
 }
 
 type binanceServiceClient struct {
@@ -39,7 +55,7 @@ func NewBinanceServiceClient(cc grpc.ClientConnInterface) BinanceServiceClient {
 	return &binanceServiceClient{cc}
 }
 
-func (c *binanceServiceClient) GetUSDTPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*USDTPricesResponse, error) {
+func (c *binanceServiceClient) GetUSDTPrices(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*USDTPricesResponse, error) {
 	out := new(USDTPricesResponse)
 	err := c.cc.Invoke(ctx, BinanceService_GetUSDTPrices_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -48,7 +64,7 @@ func (c *binanceServiceClient) GetUSDTPrices(ctx context.Context, in *Empty, opt
 	return out, nil
 }
 
-func (c *binanceServiceClient) Get24HChangePercent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangePercentResponse, error) {
+func (c *binanceServiceClient) Get24HChangePercent(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*ChangePercentResponse, error) {
 	out := new(ChangePercentResponse)
 	err := c.cc.Invoke(ctx, BinanceService_Get24HChangePercent_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -57,12 +73,161 @@ func (c *binanceServiceClient) Get24HChangePercent(ctx context.Context, in *Empt
 	return out, nil
 }
 
+func (c *binanceServiceClient) GetKlines(ctx context.Context, in *KlinesRequest, opts ...grpc.CallOption) (*KlinesResponse, error) {
+	out := new(KlinesResponse)
+	err := c.cc.Invoke(ctx, BinanceService_GetKlines_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binanceServiceClient) GetSymbols(ctx context.Context, in *MarketRequest, opts ...grpc.CallOption) (*SymbolsResponse, error) {
+	out := new(SymbolsResponse)
+	err := c.cc.Invoke(ctx, BinanceService_GetSymbols_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binanceServiceClient) GetExchanges(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExchangesResponse, error) {
+	out := new(ExchangesResponse)
+	err := c.cc.Invoke(ctx, BinanceService_GetExchanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binanceServiceClient) GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, BinanceService_GetOrderBook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binanceServiceClient) StreamOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (BinanceService_StreamOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BinanceService_ServiceDesc.Streams[0], BinanceService_StreamOrderBook_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &binanceServiceStreamOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BinanceService_StreamOrderBookClient interface {
+	Recv() (*OrderBook, error)
+	grpc.ClientStream
+}
+
+type binanceServiceStreamOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *binanceServiceStreamOrderBookClient) Recv() (*OrderBook, error) {
+	m := new(OrderBook)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *binanceServiceClient) SetInterest(ctx context.Context, in *InterestRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, BinanceService_SetInterest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binanceServiceClient) StreamWhaleTrades(ctx context.Context, in *WhaleTradesRequest, opts ...grpc.CallOption) (BinanceService_StreamWhaleTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BinanceService_ServiceDesc.Streams[1], BinanceService_StreamWhaleTrades_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &binanceServiceStreamWhaleTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BinanceService_StreamWhaleTradesClient interface {
+	Recv() (*WhaleTrade, error)
+	grpc.ClientStream
+}
+
+type binanceServiceStreamWhaleTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *binanceServiceStreamWhaleTradesClient) Recv() (*WhaleTrade, error) {
+	m := new(WhaleTrade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *binanceServiceClient) StreamListingEvents(ctx context.Context, in *ListingEventsRequest, opts ...grpc.CallOption) (BinanceService_StreamListingEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BinanceService_ServiceDesc.Streams[2], BinanceService_StreamListingEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &binanceServiceStreamListingEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BinanceService_StreamListingEventsClient interface {
+	Recv() (*ListingEvent, error)
+	grpc.ClientStream
+}
+
+type binanceServiceStreamListingEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *binanceServiceStreamListingEventsClient) Recv() (*ListingEvent, error) {
+	m := new(ListingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BinanceServiceServer is the server API for BinanceService service.
 // All implementations must embed UnimplementedBinanceServiceServer
 // for forward compatibility
 type BinanceServiceServer interface {
-	GetUSDTPrices(context.Context, *Empty) (*USDTPricesResponse, error)
-	Get24HChangePercent(context.Context, *Empty) (*ChangePercentResponse, error)
+	GetUSDTPrices(context.Context, *MarketRequest) (*USDTPricesResponse, error)
+	Get24HChangePercent(context.Context, *MarketRequest) (*ChangePercentResponse, error)
+	GetKlines(context.Context, *KlinesRequest) (*KlinesResponse, error)
+	GetSymbols(context.Context, *MarketRequest) (*SymbolsResponse, error)
+	GetExchanges(context.Context, *Empty) (*ExchangesResponse, error)
+	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error)
+	StreamOrderBook(*OrderBookRequest, BinanceService_StreamOrderBookServer) error
+	SetInterest(context.Context, *InterestRequest) (*Empty, error)
+	StreamWhaleTrades(*WhaleTradesRequest, BinanceService_StreamWhaleTradesServer) error
+	StreamListingEvents(*ListingEventsRequest, BinanceService_StreamListingEventsServer) error
 	mustEmbedUnimplementedBinanceServiceServer()
 }
 
@@ -70,12 +235,36 @@ type BinanceServiceServer interface {
 type UnimplementedBinanceServiceServer struct {
 }
 
-func (UnimplementedBinanceServiceServer) GetUSDTPrices(context.Context, *Empty) (*USDTPricesResponse, error) {
+func (UnimplementedBinanceServiceServer) GetUSDTPrices(context.Context, *MarketRequest) (*USDTPricesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUSDTPrices not implemented")
 }
-func (UnimplementedBinanceServiceServer) Get24HChangePercent(context.Context, *Empty) (*ChangePercentResponse, error) {
+func (UnimplementedBinanceServiceServer) Get24HChangePercent(context.Context, *MarketRequest) (*ChangePercentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get24HChangePercent not implemented")
 }
+func (UnimplementedBinanceServiceServer) GetKlines(context.Context, *KlinesRequest) (*KlinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKlines not implemented")
+}
+func (UnimplementedBinanceServiceServer) GetSymbols(context.Context, *MarketRequest) (*SymbolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbols not implemented")
+}
+func (UnimplementedBinanceServiceServer) GetExchanges(context.Context, *Empty) (*ExchangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchanges not implemented")
+}
+func (UnimplementedBinanceServiceServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedBinanceServiceServer) StreamOrderBook(*OrderBookRequest, BinanceService_StreamOrderBookServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderBook not implemented")
+}
+func (UnimplementedBinanceServiceServer) SetInterest(context.Context, *InterestRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterest not implemented")
+}
+func (UnimplementedBinanceServiceServer) StreamWhaleTrades(*WhaleTradesRequest, BinanceService_StreamWhaleTradesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamWhaleTrades not implemented")
+}
+func (UnimplementedBinanceServiceServer) StreamListingEvents(*ListingEventsRequest, BinanceService_StreamListingEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamListingEvents not implemented")
+}
 func (UnimplementedBinanceServiceServer) mustEmbedUnimplementedBinanceServiceServer() {}
 
 // UnsafeBinanceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -90,7 +279,7 @@ func RegisterBinanceServiceServer(s grpc.ServiceRegistrar, srv BinanceServiceSer
 }
 
 func _BinanceService_GetUSDTPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(MarketRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -102,13 +291,13 @@ func _BinanceService_GetUSDTPrices_Handler(srv interface{}, ctx context.Context,
 		FullMethod: BinanceService_GetUSDTPrices_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BinanceServiceServer).GetUSDTPrices(ctx, req.(*Empty))
+		return srv.(BinanceServiceServer).GetUSDTPrices(ctx, req.(*MarketRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _BinanceService_Get24HChangePercent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(MarketRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -120,11 +309,164 @@ func _BinanceService_Get24HChangePercent_Handler(srv interface{}, ctx context.Co
 		FullMethod: BinanceService_Get24HChangePercent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BinanceServiceServer).Get24HChangePercent(ctx, req.(*Empty))
+		return srv.(BinanceServiceServer).Get24HChangePercent(ctx, req.(*MarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinanceService_GetKlines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KlinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinanceServiceServer).GetKlines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinanceService_GetKlines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinanceServiceServer).GetKlines(ctx, req.(*KlinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinanceService_GetSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinanceServiceServer).GetSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinanceService_GetSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinanceServiceServer).GetSymbols(ctx, req.(*MarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinanceService_GetExchanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinanceServiceServer).GetExchanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinanceService_GetExchanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinanceServiceServer).GetExchanges(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinanceService_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinanceServiceServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinanceService_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinanceServiceServer).GetOrderBook(ctx, req.(*OrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinanceService_StreamOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OrderBookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BinanceServiceServer).StreamOrderBook(m, &binanceServiceStreamOrderBookServer{stream})
+}
+
+type BinanceService_StreamOrderBookServer interface {
+	Send(*OrderBook) error
+	grpc.ServerStream
+}
+
+type binanceServiceStreamOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *binanceServiceStreamOrderBookServer) Send(m *OrderBook) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BinanceService_SetInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinanceServiceServer).SetInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinanceService_SetInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinanceServiceServer).SetInterest(ctx, req.(*InterestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BinanceService_StreamWhaleTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WhaleTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BinanceServiceServer).StreamWhaleTrades(m, &binanceServiceStreamWhaleTradesServer{stream})
+}
+
+type BinanceService_StreamWhaleTradesServer interface {
+	Send(*WhaleTrade) error
+	grpc.ServerStream
+}
+
+type binanceServiceStreamWhaleTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *binanceServiceStreamWhaleTradesServer) Send(m *WhaleTrade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BinanceService_StreamListingEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListingEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BinanceServiceServer).StreamListingEvents(m, &binanceServiceStreamListingEventsServer{stream})
+}
+
+type BinanceService_StreamListingEventsServer interface {
+	Send(*ListingEvent) error
+	grpc.ServerStream
+}
+
+type binanceServiceStreamListingEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *binanceServiceStreamListingEventsServer) Send(m *ListingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // BinanceService_ServiceDesc is the grpc.ServiceDesc for BinanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,7 +482,593 @@ var BinanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get24hChangePercent",
 			Handler:    _BinanceService_Get24HChangePercent_Handler,
 		},
+		{
+			MethodName: "GetKlines",
+			Handler:    _BinanceService_GetKlines_Handler,
+		},
+		{
+			MethodName: "GetSymbols",
+			Handler:    _BinanceService_GetSymbols_Handler,
+		},
+		{
+			MethodName: "GetExchanges",
+			Handler:    _BinanceService_GetExchanges_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _BinanceService_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "SetInterest",
+			Handler:    _BinanceService_SetInterest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOrderBook",
+			Handler:       _BinanceService_StreamOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamWhaleTrades",
+			Handler:       _BinanceService_StreamWhaleTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamListingEvents",
+			Handler:       _BinanceService_StreamListingEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "binance.proto",
+}
+
+const (
+	FuturesService_GetFuturesPrices_FullMethodName           = "/binance.FuturesService/GetFuturesPrices"
+	FuturesService_GetFutures24HChangePercent_FullMethodName = "/binance.FuturesService/GetFutures24hChangePercent"
+	FuturesService_GetFuturesKlines_FullMethodName           = "/binance.FuturesService/GetFuturesKlines"
+	FuturesService_GetMarkPrices_FullMethodName              = "/binance.FuturesService/GetMarkPrices"
+	FuturesService_GetOpenInterest_FullMethodName            = "/binance.FuturesService/GetOpenInterest"
+	FuturesService_GetOpenInterestHistory_FullMethodName     = "/binance.FuturesService/GetOpenInterestHistory"
+	FuturesService_GetFundingRates_FullMethodName            = "/binance.FuturesService/GetFundingRates"
+)
+
+// FuturesServiceClient is the client API for FuturesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FuturesServiceClient interface {
+	GetFuturesPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*USDTPricesResponse, error)
+	GetFutures24HChangePercent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangePercentResponse, error)
+	GetFuturesKlines(ctx context.Context, in *KlinesRequest, opts ...grpc.CallOption) (*KlinesResponse, error)
+	GetMarkPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MarkPricesResponse, error)
+	GetOpenInterest(ctx context.Context, in *OpenInterestRequest, opts ...grpc.CallOption) (*OpenInterestResponse, error)
+	GetOpenInterestHistory(ctx context.Context, in *OpenInterestHistoryRequest, opts ...grpc.CallOption) (*OpenInterestResponse, error)
+	GetFundingRates(ctx context.Context, in *FundingRatesRequest, opts ...grpc.CallOption) (*FundingRatesResponse, error)
+}
+
+type futuresServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFuturesServiceClient(cc grpc.ClientConnInterface) FuturesServiceClient {
+	return &futuresServiceClient{cc}
+}
+
+func (c *futuresServiceClient) GetFuturesPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*USDTPricesResponse, error) {
+	out := new(USDTPricesResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetFuturesPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetFutures24HChangePercent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangePercentResponse, error) {
+	out := new(ChangePercentResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetFutures24HChangePercent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetFuturesKlines(ctx context.Context, in *KlinesRequest, opts ...grpc.CallOption) (*KlinesResponse, error) {
+	out := new(KlinesResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetFuturesKlines_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetMarkPrices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MarkPricesResponse, error) {
+	out := new(MarkPricesResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetMarkPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetOpenInterest(ctx context.Context, in *OpenInterestRequest, opts ...grpc.CallOption) (*OpenInterestResponse, error) {
+	out := new(OpenInterestResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetOpenInterest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetOpenInterestHistory(ctx context.Context, in *OpenInterestHistoryRequest, opts ...grpc.CallOption) (*OpenInterestResponse, error) {
+	out := new(OpenInterestResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetOpenInterestHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *futuresServiceClient) GetFundingRates(ctx context.Context, in *FundingRatesRequest, opts ...grpc.CallOption) (*FundingRatesResponse, error) {
+	out := new(FundingRatesResponse)
+	err := c.cc.Invoke(ctx, FuturesService_GetFundingRates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FuturesServiceServer is the server API for FuturesService service.
+// All implementations must embed UnimplementedFuturesServiceServer
+// for forward compatibility
+type FuturesServiceServer interface {
+	GetFuturesPrices(context.Context, *Empty) (*USDTPricesResponse, error)
+	GetFutures24HChangePercent(context.Context, *Empty) (*ChangePercentResponse, error)
+	GetFuturesKlines(context.Context, *KlinesRequest) (*KlinesResponse, error)
+	GetMarkPrices(context.Context, *Empty) (*MarkPricesResponse, error)
+	GetOpenInterest(context.Context, *OpenInterestRequest) (*OpenInterestResponse, error)
+	GetOpenInterestHistory(context.Context, *OpenInterestHistoryRequest) (*OpenInterestResponse, error)
+	GetFundingRates(context.Context, *FundingRatesRequest) (*FundingRatesResponse, error)
+	mustEmbedUnimplementedFuturesServiceServer()
+}
+
+// UnimplementedFuturesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFuturesServiceServer struct {
+}
+
+func (UnimplementedFuturesServiceServer) GetFuturesPrices(context.Context, *Empty) (*USDTPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuturesPrices not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetFutures24HChangePercent(context.Context, *Empty) (*ChangePercentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFutures24HChangePercent not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetFuturesKlines(context.Context, *KlinesRequest) (*KlinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuturesKlines not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetMarkPrices(context.Context, *Empty) (*MarkPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarkPrices not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetOpenInterest(context.Context, *OpenInterestRequest) (*OpenInterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenInterest not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetOpenInterestHistory(context.Context, *OpenInterestHistoryRequest) (*OpenInterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenInterestHistory not implemented")
+}
+func (UnimplementedFuturesServiceServer) GetFundingRates(context.Context, *FundingRatesRequest) (*FundingRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFundingRates not implemented")
+}
+func (UnimplementedFuturesServiceServer) mustEmbedUnimplementedFuturesServiceServer() {}
+
+// UnsafeFuturesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FuturesServiceServer will
+// result in compilation errors.
+type UnsafeFuturesServiceServer interface {
+	mustEmbedUnimplementedFuturesServiceServer()
+}
+
+func RegisterFuturesServiceServer(s grpc.ServiceRegistrar, srv FuturesServiceServer) {
+	s.RegisterService(&FuturesService_ServiceDesc, srv)
+}
+
+func _FuturesService_GetFuturesPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetFuturesPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetFuturesPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetFuturesPrices(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetFutures24HChangePercent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetFutures24HChangePercent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetFutures24HChangePercent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetFutures24HChangePercent(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetFuturesKlines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KlinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetFuturesKlines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetFuturesKlines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetFuturesKlines(ctx, req.(*KlinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetMarkPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetMarkPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetMarkPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetMarkPrices(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetOpenInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetOpenInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetOpenInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetOpenInterest(ctx, req.(*OpenInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetOpenInterestHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenInterestHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetOpenInterestHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetOpenInterestHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetOpenInterestHistory(ctx, req.(*OpenInterestHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuturesService_GetFundingRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuturesServiceServer).GetFundingRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuturesService_GetFundingRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuturesServiceServer).GetFundingRates(ctx, req.(*FundingRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FuturesService_ServiceDesc is the grpc.ServiceDesc for FuturesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FuturesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "binance.FuturesService",
+	HandlerType: (*FuturesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFuturesPrices",
+			Handler:    _FuturesService_GetFuturesPrices_Handler,
+		},
+		{
+			MethodName: "GetFutures24hChangePercent",
+			Handler:    _FuturesService_GetFutures24HChangePercent_Handler,
+		},
+		{
+			MethodName: "GetFuturesKlines",
+			Handler:    _FuturesService_GetFuturesKlines_Handler,
+		},
+		{
+			MethodName: "GetMarkPrices",
+			Handler:    _FuturesService_GetMarkPrices_Handler,
+		},
+		{
+			MethodName: "GetOpenInterest",
+			Handler:    _FuturesService_GetOpenInterest_Handler,
+		},
+		{
+			MethodName: "GetOpenInterestHistory",
+			Handler:    _FuturesService_GetOpenInterestHistory_Handler,
+		},
+		{
+			MethodName: "GetFundingRates",
+			Handler:    _FuturesService_GetFundingRates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "binance.proto",
+}
+
+const (
+	ReplayControl_GetStatus_FullMethodName = "/binance.ReplayControl/GetStatus"
+	ReplayControl_SetSpeed_FullMethodName  = "/binance.ReplayControl/SetSpeed"
+	ReplayControl_Pause_FullMethodName     = "/binance.ReplayControl/Pause"
+	ReplayControl_Resume_FullMethodName    = "/binance.ReplayControl/Resume"
+	ReplayControl_Seek_FullMethodName      = "/binance.ReplayControl/Seek"
+)
+
+// ReplayControlClient is the client API for ReplayControl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplayControlClient interface {
+	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error)
+	SetSpeed(ctx context.Context, in *SetSpeedRequest, opts ...grpc.CallOption) (*ReplayStatus, error)
+	Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error)
+	Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error)
+	Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*ReplayStatus, error)
+}
+
+type replayControlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplayControlClient(cc grpc.ClientConnInterface) ReplayControlClient {
+	return &replayControlClient{cc}
+}
+
+func (c *replayControlClient) GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error) {
+	out := new(ReplayStatus)
+	err := c.cc.Invoke(ctx, ReplayControl_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayControlClient) SetSpeed(ctx context.Context, in *SetSpeedRequest, opts ...grpc.CallOption) (*ReplayStatus, error) {
+	out := new(ReplayStatus)
+	err := c.cc.Invoke(ctx, ReplayControl_SetSpeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayControlClient) Pause(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error) {
+	out := new(ReplayStatus)
+	err := c.cc.Invoke(ctx, ReplayControl_Pause_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayControlClient) Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReplayStatus, error) {
+	out := new(ReplayStatus)
+	err := c.cc.Invoke(ctx, ReplayControl_Resume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replayControlClient) Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*ReplayStatus, error) {
+	out := new(ReplayStatus)
+	err := c.cc.Invoke(ctx, ReplayControl_Seek_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplayControlServer is the server API for ReplayControl service.
+// All implementations must embed UnimplementedReplayControlServer
+// for forward compatibility
+type ReplayControlServer interface {
+	GetStatus(context.Context, *Empty) (*ReplayStatus, error)
+	SetSpeed(context.Context, *SetSpeedRequest) (*ReplayStatus, error)
+	Pause(context.Context, *Empty) (*ReplayStatus, error)
+	Resume(context.Context, *Empty) (*ReplayStatus, error)
+	Seek(context.Context, *SeekRequest) (*ReplayStatus, error)
+	mustEmbedUnimplementedReplayControlServer()
+}
+
+// UnimplementedReplayControlServer must be embedded to have forward compatible implementations.
+type UnimplementedReplayControlServer struct {
+}
+
+func (UnimplementedReplayControlServer) GetStatus(context.Context, *Empty) (*ReplayStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedReplayControlServer) SetSpeed(context.Context, *SetSpeedRequest) (*ReplayStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpeed not implemented")
+}
+func (UnimplementedReplayControlServer) Pause(context.Context, *Empty) (*ReplayStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedReplayControlServer) Resume(context.Context, *Empty) (*ReplayStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedReplayControlServer) Seek(context.Context, *SeekRequest) (*ReplayStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Seek not implemented")
+}
+func (UnimplementedReplayControlServer) mustEmbedUnimplementedReplayControlServer() {}
+
+// UnsafeReplayControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplayControlServer will
+// result in compilation errors.
+type UnsafeReplayControlServer interface {
+	mustEmbedUnimplementedReplayControlServer()
+}
+
+func RegisterReplayControlServer(s grpc.ServiceRegistrar, srv ReplayControlServer) {
+	s.RegisterService(&ReplayControl_ServiceDesc, srv)
+}
+
+func _ReplayControl_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplayControlServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplayControl_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplayControlServer).GetStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplayControl_SetSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplayControlServer).SetSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplayControl_SetSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplayControlServer).SetSpeed(ctx, req.(*SetSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplayControl_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplayControlServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplayControl_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplayControlServer).Pause(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplayControl_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplayControlServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplayControl_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplayControlServer).Resume(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplayControl_Seek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplayControlServer).Seek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplayControl_Seek_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplayControlServer).Seek(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReplayControl_ServiceDesc is the grpc.ServiceDesc for ReplayControl service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReplayControl_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "binance.ReplayControl",
+	HandlerType: (*ReplayControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _ReplayControl_GetStatus_Handler,
+		},
+		{
+			MethodName: "SetSpeed",
+			Handler:    _ReplayControl_SetSpeed_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _ReplayControl_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _ReplayControl_Resume_Handler,
+		},
+		{
+			MethodName: "Seek",
+			Handler:    _ReplayControl_Seek_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "imPulseimPulse.proto",
+	Metadata: "binance.proto",
 }
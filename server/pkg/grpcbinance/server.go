@@ -2,25 +2,94 @@ package grpcbinance
 
 import (
 	"context"
-	"github.com/adshao/go-binance/v2"
+	"github.com/agopankov/imPulse/server/pkg/exchange"
 	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
-	"strconv"
+	"github.com/agopankov/imPulse/server/pkg/listings"
+	"github.com/agopankov/imPulse/server/pkg/whales"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
 	"strings"
+	"time"
 )
 
+type Recorder interface {
+	RecordPrices(at time.Time, prices []*proto.USDTPrice)
+	RecordTickers(at time.Time, tickers []*proto.ChangePercent)
+}
+
 type BinanceServiceServer struct {
 	proto.UnimplementedBinanceServiceServer
-	client *binance.Client
+	binance   *exchange.Binance
+	exchanges *exchange.Registry
+	whales    *whales.Hub
+	listings  *listings.Detector
 }
 
 func NewBinanceServiceServer(apiKey, secretKey string) *BinanceServiceServer {
+	binance := exchange.NewBinance(apiKey, secretKey)
 	return &BinanceServiceServer{
-		client: binance.NewClient(apiKey, secretKey),
+		binance:   binance,
+		exchanges: exchange.NewRegistry(binance),
+		whales:    whales.NewHub(binance.StreamAggTrades),
+		listings:  listings.NewDetector(binance.Symbols),
 	}
 }
 
-func (s *BinanceServiceServer) GetUSDTPrices(ctx context.Context, _ *proto.Empty) (*proto.USDTPricesResponse, error) {
-	prices, err := s.client.NewListPricesService().Do(ctx)
+func (s *BinanceServiceServer) AddExchange(venue exchange.Exchange) {
+	s.exchanges.Add(venue)
+}
+
+func (s *BinanceServiceServer) SetBaseURL(baseURL string) {
+	s.binance.SetBaseURL(baseURL)
+}
+
+func (s *BinanceServiceServer) SetStreamURL(streamURL string) {
+	s.binance.SetStreamURL(streamURL)
+}
+
+// Record polls the Binance prices and 24h stats every interval until ctx is
+// done and hands them to recorder. It is one poll for the server, the clients
+// polling the same data would record each snapshot once per client.
+func (s *BinanceServiceServer) Record(ctx context.Context, recorder Recorder, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.recordSnapshot(ctx, recorder)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *BinanceServiceServer) recordSnapshot(ctx context.Context, recorder Recorder) {
+	prices, err := s.GetUSDTPrices(ctx, &proto.MarketRequest{})
+	if err != nil {
+		log.Printf("Error getting prices to record: %v", err)
+	} else {
+		recorder.RecordPrices(time.Now(), prices.Prices)
+	}
+
+	changePercents, err := s.Get24HChangePercent(ctx, &proto.MarketRequest{})
+	if err != nil {
+		log.Printf("Error getting 24h stats to record: %v", err)
+	} else {
+		recorder.RecordTickers(time.Now(), changePercents.ChangePercents)
+	}
+}
+
+func (s *BinanceServiceServer) GetUSDTPrices(ctx context.Context, req *proto.MarketRequest) (*proto.USDTPricesResponse, error) {
+	venue, err := s.exchange(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := venue.Prices(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -28,10 +97,9 @@ func (s *BinanceServiceServer) GetUSDTPrices(ctx context.Context, _ *proto.Empty
 	usdtPrices := make([]*proto.USDTPrice, 0)
 	for _, price := range prices {
 		if strings.HasSuffix(price.Symbol, "USDT") {
-			priceFloat, _ := strconv.ParseFloat(price.Price, 64)
 			usdtPrice := &proto.USDTPrice{
 				Symbol: price.Symbol,
-				Price:  priceFloat,
+				Price:  price.Price,
 			}
 			usdtPrices = append(usdtPrices, usdtPrice)
 		}
@@ -43,21 +111,23 @@ func (s *BinanceServiceServer) GetUSDTPrices(ctx context.Context, _ *proto.Empty
 	return response, nil
 }
 
-func (s *BinanceServiceServer) Get24HChangePercent(ctx context.Context, _ *proto.Empty) (*proto.ChangePercentResponse, error) {
-	ticker24h, err := s.client.NewListPriceChangeStatsService().Do(ctx)
+func (s *BinanceServiceServer) Get24HChangePercent(ctx context.Context, req *proto.MarketRequest) (*proto.ChangePercentResponse, error) {
+	venue, err := s.exchange(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers, err := venue.Tickers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	changePercents := make([]*proto.ChangePercent, 0)
-	for _, ticker := range ticker24h {
-		change, err := strconv.ParseFloat(ticker.PriceChangePercent, 64)
-		if err != nil {
-			continue
-		}
+	for _, ticker := range tickers {
 		changePercent := &proto.ChangePercent{
 			Symbol:        ticker.Symbol,
-			ChangePercent: change,
+			ChangePercent: ticker.ChangePercent,
+			QuoteVolume:   ticker.QuoteVolume,
 		}
 		changePercents = append(changePercents, changePercent)
 	}
@@ -67,3 +137,80 @@ func (s *BinanceServiceServer) Get24HChangePercent(ctx context.Context, _ *proto
 	}
 	return response, nil
 }
+
+func (s *BinanceServiceServer) GetKlines(ctx context.Context, req *proto.KlinesRequest) (*proto.KlinesResponse, error) {
+	venue, err := s.exchange(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := req.Interval
+	if interval == "" {
+		interval = "1m"
+	}
+
+	klines, err := venue.Klines(ctx, exchange.KlinesQuery{
+		Symbol:    req.Symbol,
+		Interval:  interval,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Limit:     int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	protoKlines := make([]*proto.Kline, 0, len(klines))
+	for _, kline := range klines {
+		protoKlines = append(protoKlines, &proto.Kline{
+			OpenTime:    kline.OpenTime,
+			Open:        kline.Open,
+			High:        kline.High,
+			Low:         kline.Low,
+			Close:       kline.Close,
+			Volume:      kline.Volume,
+			QuoteVolume: kline.QuoteVolume,
+			CloseTime:   kline.CloseTime,
+		})
+	}
+
+	response := &proto.KlinesResponse{
+		Klines: protoKlines,
+	}
+	return response, nil
+}
+
+func (s *BinanceServiceServer) GetSymbols(ctx context.Context, req *proto.MarketRequest) (*proto.SymbolsResponse, error) {
+	venue, err := s.exchange(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := venue.Symbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	protoSymbols := make([]*proto.SymbolInfo, 0, len(symbols))
+	for _, symbol := range symbols {
+		protoSymbols = append(protoSymbols, &proto.SymbolInfo{
+			Symbol:     symbol.Symbol,
+			BaseAsset:  symbol.BaseAsset,
+			QuoteAsset: symbol.QuoteAsset,
+			Trading:    symbol.Trading,
+		})
+	}
+	return &proto.SymbolsResponse{Symbols: protoSymbols}, nil
+}
+
+func (s *BinanceServiceServer) GetExchanges(context.Context, *proto.Empty) (*proto.ExchangesResponse, error) {
+	return &proto.ExchangesResponse{Exchanges: s.exchanges.Names()}, nil
+}
+
+func (s *BinanceServiceServer) exchange(name string) (exchange.Exchange, error) {
+	venue, err := s.exchanges.Get(name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return venue, nil
+}
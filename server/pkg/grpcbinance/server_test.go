@@ -0,0 +1,228 @@
+package grpcbinance
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/server/pkg/fakebinance"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+)
+
+var testScript = &fakebinance.Script{
+	Frames: []fakebinance.Frame{
+		{
+			Time: 1700000000000,
+			Tickers: []fakebinance.Ticker{
+				{Symbol: "BTCUSDT", Price: 36500, ChangePercent: 1.2, Volume: 21000, QuoteVolume: 766500000},
+				{Symbol: "PEPEUSDT", Price: 0.0000012, ChangePercent: 18.5, Volume: 9000000000000, QuoteVolume: 10800000},
+				{Symbol: "ETHBTC", Price: 0.0563, ChangePercent: -0.4, Volume: 41000, QuoteVolume: 2308},
+			},
+		},
+	},
+	Klines: map[string][]fakebinance.Kline{
+		"PEPEUSDT": {
+			{OpenTime: 1699999860000, Open: 0.00000118, High: 0.0000012, Low: 0.00000117, Close: 0.0000012, Volume: 120000000000, QuoteVolume: 142000, CloseTime: 1699999919999},
+			{OpenTime: 1699999920000, Open: 0.0000012, High: 0.00000121, Low: 0.00000119, Close: 0.0000012, Volume: 110000000000, QuoteVolume: 132000, CloseTime: 1699999979999},
+			{OpenTime: 1699999980000, Open: 0.0000012, High: 0.0000013, Low: 0.0000012, Close: 0.0000013, Volume: 400000000000, QuoteVolume: 500000, CloseTime: 1700000039999},
+		},
+	},
+}
+
+func newTestServer(t *testing.T) *BinanceServiceServer {
+	t.Helper()
+	fake, err := fakebinance.New(testScript)
+	if err != nil {
+		t.Fatalf("fakebinance.New: %v", err)
+	}
+	httpServer := httptest.NewServer(fake.Handler())
+	t.Cleanup(httpServer.Close)
+
+	server := NewBinanceServiceServer("", "")
+	server.SetBaseURL(httpServer.URL)
+	return server
+}
+
+func TestGetUSDTPrices(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := server.GetUSDTPrices(context.Background(), &proto.MarketRequest{})
+	if err != nil {
+		t.Fatalf("GetUSDTPrices: %v", err)
+	}
+
+	want := map[string]float64{"BTCUSDT": 36500, "PEPEUSDT": 0.0000012}
+	if len(resp.Prices) != len(want) {
+		t.Fatalf("got %d prices, want %d: %v", len(resp.Prices), len(want), resp.Prices)
+	}
+	for _, price := range resp.Prices {
+		if price.Price != want[price.Symbol] {
+			t.Errorf("%s price = %v, want %v", price.Symbol, price.Price, want[price.Symbol])
+		}
+	}
+}
+
+func TestGet24HChangePercent(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := server.Get24HChangePercent(context.Background(), &proto.MarketRequest{})
+	if err != nil {
+		t.Fatalf("Get24HChangePercent: %v", err)
+	}
+
+	got := make(map[string]*proto.ChangePercent, len(resp.ChangePercents))
+	for _, change := range resp.ChangePercents {
+		got[change.Symbol] = change
+	}
+	tests := []struct {
+		symbol      string
+		change      float64
+		quoteVolume float64
+	}{
+		{"BTCUSDT", 1.2, 766500000},
+		{"PEPEUSDT", 18.5, 10800000},
+		{"ETHBTC", -0.4, 2308},
+	}
+	for _, tt := range tests {
+		change, ok := got[tt.symbol]
+		if !ok {
+			t.Errorf("%s missing", tt.symbol)
+			continue
+		}
+		if change.ChangePercent != tt.change || change.QuoteVolume != tt.quoteVolume {
+			t.Errorf("%s = %v%% / %v, want %v%% / %v", tt.symbol, change.ChangePercent, change.QuoteVolume, tt.change, tt.quoteVolume)
+		}
+	}
+}
+
+func TestGetKlines(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := server.GetKlines(context.Background(), &proto.KlinesRequest{Symbol: "PEPEUSDT", Interval: "1m", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetKlines: %v", err)
+	}
+
+	if len(resp.Klines) != 2 {
+		t.Fatalf("got %d klines, want 2", len(resp.Klines))
+	}
+	last := resp.Klines[1]
+	if last.OpenTime != 1699999980000 || last.Close != 0.0000013 || last.QuoteVolume != 500000 || last.CloseTime != 1700000039999 {
+		t.Errorf("last kline = %+v", last)
+	}
+	if resp.Klines[0].OpenTime != 1699999920000 {
+		t.Errorf("first kline opens at %d, want the newest two", resp.Klines[0].OpenTime)
+	}
+}
+
+func TestGetSymbols(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := server.GetSymbols(context.Background(), &proto.MarketRequest{})
+	if err != nil {
+		t.Fatalf("GetSymbols: %v", err)
+	}
+
+	want := map[string][2]string{
+		"BTCUSDT":  {"BTC", "USDT"},
+		"PEPEUSDT": {"PEPE", "USDT"},
+		"ETHBTC":   {"ETH", "BTC"},
+	}
+	if len(resp.Symbols) != len(want) {
+		t.Fatalf("got %d symbols, want %d", len(resp.Symbols), len(want))
+	}
+	for _, symbol := range resp.Symbols {
+		assets, ok := want[symbol.Symbol]
+		if !ok {
+			t.Errorf("unexpected symbol %s", symbol.Symbol)
+			continue
+		}
+		if symbol.BaseAsset != assets[0] || symbol.QuoteAsset != assets[1] || !symbol.Trading {
+			t.Errorf("%s = %+v", symbol.Symbol, symbol)
+		}
+	}
+}
+
+func TestGetOrderBookCoversBand(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		limit    int32
+		band     float64
+		levels   int
+		complete bool
+	}{
+		{"default depth covers 2%", 0, 2, 100, true},
+		{"wide band fetches deeper", 0, 15, 1000, true},
+		{"fixed limit stays shallow", 20, 5, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := server.GetOrderBook(context.Background(), &proto.OrderBookRequest{Symbol: "BTCUSDT", Limit: tt.limit, BandPercent: tt.band})
+			if err != nil {
+				t.Fatalf("GetOrderBook: %v", err)
+			}
+			if len(book.Bids) != tt.levels || len(book.Asks) != tt.levels {
+				t.Errorf("got %d bids and %d asks, want %d", len(book.Bids), len(book.Asks), tt.levels)
+			}
+			if book.Liquidity.Complete != tt.complete {
+				t.Errorf("complete = %v, want %v", book.Liquidity.Complete, tt.complete)
+			}
+		})
+	}
+}
+
+type countingRecorder struct {
+	mu      sync.Mutex
+	prices  int
+	tickers int
+}
+
+func (r *countingRecorder) RecordPrices(time.Time, []*proto.USDTPrice) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prices++
+}
+
+func (r *countingRecorder) RecordTickers(time.Time, []*proto.ChangePercent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tickers++
+}
+
+func (r *countingRecorder) counts() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.prices, r.tickers
+}
+
+func TestRecordPollsOnceForAllClients(t *testing.T) {
+	server := newTestServer(t)
+	recorder := &countingRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server.Record(ctx, recorder, time.Hour)
+	deadline := time.Now().Add(5 * time.Second)
+	for prices, tickers := recorder.counts(); prices == 0 || tickers == 0; prices, tickers = recorder.counts() {
+		if time.Now().After(deadline) {
+			t.Fatal("Record didn't take a first snapshot")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := server.GetUSDTPrices(context.Background(), &proto.MarketRequest{}); err != nil {
+			t.Fatalf("GetUSDTPrices: %v", err)
+		}
+		if _, err := server.Get24HChangePercent(context.Background(), &proto.MarketRequest{}); err != nil {
+			t.Fatalf("Get24HChangePercent: %v", err)
+		}
+	}
+	if prices, tickers := recorder.counts(); prices != 1 || tickers != 1 {
+		t.Errorf("recorded %d prices and %d tickers, want one of each for the interval", prices, tickers)
+	}
+}
@@ -0,0 +1,66 @@
+package grpcbinance
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"github.com/agopankov/imPulse/server/pkg/whales"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const maxInterestSymbols = 200
+
+func (s *BinanceServiceServer) SetInterest(_ context.Context, req *proto.InterestRequest) (*proto.Empty, error) {
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if len(req.Symbols) > maxInterestSymbols {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d symbols per client", maxInterestSymbols)
+	}
+
+	s.whales.SetInterest(req.ClientId, req.Symbols)
+	return &proto.Empty{}, nil
+}
+
+// StreamWhaleTrades sends the large trades and bursts on the symbols the
+// client registered with SetInterest until the client goes away.
+func (s *BinanceServiceServer) StreamWhaleTrades(req *proto.WhaleTradesRequest, stream proto.BinanceService_StreamWhaleTradesServer) error {
+	if req.ClientId == "" {
+		return status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if req.MinNotional < 0 || req.BurstNotional < 0 || req.BurstWindowSeconds < 0 {
+		return status.Error(codes.InvalidArgument, "thresholds can't be negative")
+	}
+	if req.MinNotional == 0 && (req.BurstNotional == 0 || req.BurstWindowSeconds == 0) {
+		return status.Error(codes.InvalidArgument, "min_notional or burst_notional with burst_window_seconds is required")
+	}
+
+	events, cancel := s.whales.Subscribe(req.ClientId, whales.Settings{
+		MinNotional:   req.MinNotional,
+		BurstNotional: req.BurstNotional,
+		BurstWindow:   time.Duration(req.BurstWindowSeconds) * time.Second,
+	})
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			err := stream.Send(&proto.WhaleTrade{
+				Symbol:   event.Symbol,
+				Side:     event.Side,
+				Price:    event.Price,
+				Quantity: event.Quantity,
+				Notional: event.Notional,
+				Trades:   int32(event.Trades),
+				Time:     event.Time,
+				Burst:    event.Burst,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
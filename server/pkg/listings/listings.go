@@ -0,0 +1,213 @@
+package listings
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	KindNew       = "new"
+	KindTrading   = "trading"
+	KindDelisting = "delisting"
+	KindStatus    = "status"
+
+	DefaultInterval = 30 * time.Second
+
+	// RemovedStatus stands for the status of a symbol that is no longer listed.
+	RemovedStatus = "REMOVED"
+	HaltStatus    = "HALT"
+	BreakStatus   = "BREAK"
+
+	historySize = 100
+	eventBuffer = 32
+)
+
+// Source lists the symbols of the exchange with their status.
+type Source func(ctx context.Context) ([]exchange.Symbol, error)
+
+// Event is a change of the listed symbols. A symbol that appears is new,
+// whatever its status, and one that is halted or removed is delisting. A
+// symbol that starts trading for the first time, not coming back from a
+// break, is trading. Every other change, like the BREAK all symbols go
+// through during system maintenance and the way back, is a status change.
+type Event struct {
+	Symbol         string
+	Kind           string
+	BaseAsset      string
+	QuoteAsset     string
+	Status         string
+	PreviousStatus string
+	Time           time.Time
+	// Seq orders the events, even those of the same poll. It follows the
+	// clock in milliseconds, so it keeps growing across server restarts.
+	Seq int64
+}
+
+// Detector polls the symbol list, diffs it with the previous one and hands
+// the changes to its subscribers. The first poll only sets the baseline.
+// Recent events are kept so a client that reconnects can catch up.
+type Detector struct {
+	source Source
+
+	mu          sync.Mutex
+	symbols     map[string]exchange.Symbol
+	seq         int64
+	history     []Event
+	subscribers map[chan Event]bool
+	once        sync.Once
+}
+
+func NewDetector(source Source) *Detector {
+	return &Detector{source: source, subscribers: make(map[chan Event]bool)}
+}
+
+// Start polls every interval until the context ends. Only the first call
+// starts polling.
+func (d *Detector) Start(ctx context.Context, interval time.Duration) {
+	d.once.Do(func() {
+		go d.run(ctx, interval)
+	})
+}
+
+// Subscribe returns the remembered events numbered after afterSeq followed
+// by the live ones, along with the number of the last event so far. With
+// afterSeq 0 only live events come. An afterSeq from before a restart is
+// below every event since, so they are all replayed; one ahead of the last
+// event, after the clock went back, replays them all too. The
+// channel is closed by the returned cancel function; events are dropped
+// while the subscriber is not keeping up.
+func (d *Detector) Subscribe(afterSeq int64) (<-chan Event, int64, func()) {
+	events := make(chan Event, historySize+eventBuffer)
+	d.mu.Lock()
+	latest := d.seq
+	if afterSeq > latest {
+		afterSeq = 0
+	} else if afterSeq == 0 {
+		afterSeq = latest
+	}
+	for _, event := range d.history {
+		if event.Seq > afterSeq {
+			events <- event
+		}
+	}
+	d.subscribers[events] = true
+	d.mu.Unlock()
+
+	return events, latest, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.subscribers[events] {
+			delete(d.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+func (d *Detector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Detector) poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	symbols, err := d.source(ctx)
+	if err != nil {
+		log.Printf("Error listing symbols: %v", err)
+		return
+	}
+
+	current := make(map[string]exchange.Symbol, len(symbols))
+	for _, symbol := range symbols {
+		current[symbol.Symbol] = symbol
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.symbols == nil {
+		d.symbols = current
+		log.Printf("Listing baseline of %d symbols", len(current))
+		return
+	}
+
+	now := time.Now()
+	events := Diff(d.symbols, current, now)
+	d.symbols = current
+	for _, event := range events {
+		d.seq++
+		if d.seq < now.UnixMilli() {
+			d.seq = now.UnixMilli()
+		}
+		event.Seq = d.seq
+		log.Printf("Listing event %s %s (%s -> %s)", event.Kind, event.Symbol, event.PreviousStatus, event.Status)
+		d.history = append(d.history, event)
+		for subscriber := range d.subscribers {
+			select {
+			case subscriber <- event:
+			default:
+				log.Printf("Dropped listing event for %s, subscriber is not keeping up", event.Symbol)
+			}
+		}
+	}
+	if len(d.history) > historySize {
+		d.history = d.history[len(d.history)-historySize:]
+	}
+}
+
+// Diff compares two symbol lists keyed by symbol, events come sorted by
+// symbol.
+func Diff(previous, current map[string]exchange.Symbol, at time.Time) []Event {
+	var events []Event
+	for name, symbol := range current {
+		old, listed := previous[name]
+		switch {
+		case !listed:
+			events = append(events, newEvent(symbol, KindNew, "", at))
+		case symbol.Status == old.Status && symbol.Trading == old.Trading:
+		case symbol.Status == HaltStatus:
+			events = append(events, newEvent(symbol, KindDelisting, old.Status, at))
+		case symbol.Trading && !old.Trading && old.Status != BreakStatus:
+			events = append(events, newEvent(symbol, KindTrading, old.Status, at))
+		default:
+			events = append(events, newEvent(symbol, KindStatus, old.Status, at))
+		}
+	}
+	for name, old := range previous {
+		if _, listed := current[name]; !listed {
+			removed := old
+			removed.Status = RemovedStatus
+			events = append(events, newEvent(removed, KindDelisting, old.Status, at))
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Symbol < events[j].Symbol
+	})
+	return events
+}
+
+func newEvent(symbol exchange.Symbol, kind string, previousStatus string, at time.Time) Event {
+	return Event{
+		Symbol:         symbol.Symbol,
+		Kind:           kind,
+		BaseAsset:      symbol.BaseAsset,
+		QuoteAsset:     symbol.QuoteAsset,
+		Status:         symbol.Status,
+		PreviousStatus: previousStatus,
+		Time:           at,
+	}
+}
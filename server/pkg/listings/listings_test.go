@@ -0,0 +1,112 @@
+package listings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+)
+
+func symbol(name, status string) exchange.Symbol {
+	return exchange.Symbol{Symbol: name, BaseAsset: name[:len(name)-4], QuoteAsset: "USDT", Status: status, Trading: status == "TRADING"}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous exchange.Symbol
+		current  exchange.Symbol
+		kind     string
+	}{
+		{"new", exchange.Symbol{}, symbol("NEWUSDT", "PRE_TRADING"), KindNew},
+		{"first trading", symbol("NEWUSDT", "PRE_TRADING"), symbol("NEWUSDT", "TRADING"), KindTrading},
+		{"halt", symbol("OLDUSDT", "TRADING"), symbol("OLDUSDT", "HALT"), KindDelisting},
+		{"removed", symbol("OLDUSDT", "BREAK"), exchange.Symbol{}, KindDelisting},
+		{"break", symbol("BTCUSDT", "TRADING"), symbol("BTCUSDT", "BREAK"), KindStatus},
+		{"back from break", symbol("BTCUSDT", "BREAK"), symbol("BTCUSDT", "TRADING"), KindStatus},
+		{"unchanged", symbol("BTCUSDT", "TRADING"), symbol("BTCUSDT", "TRADING"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := map[string]exchange.Symbol{}
+			if tt.previous.Symbol != "" {
+				previous[tt.previous.Symbol] = tt.previous
+			}
+			current := map[string]exchange.Symbol{}
+			if tt.current.Symbol != "" {
+				current[tt.current.Symbol] = tt.current
+			}
+
+			events := Diff(previous, current, time.Unix(0, 0))
+			if tt.kind == "" {
+				if len(events) != 0 {
+					t.Fatalf("got %+v, want no events", events)
+				}
+				return
+			}
+			if len(events) != 1 || events[0].Kind != tt.kind {
+				t.Fatalf("got %+v, want one %s event", events, tt.kind)
+			}
+		})
+	}
+}
+
+func TestSubscribeResumesAfterSeq(t *testing.T) {
+	var listed []exchange.Symbol
+	detector := NewDetector(func(ctx context.Context) ([]exchange.Symbol, error) {
+		return listed, nil
+	})
+	detector.poll(context.Background())
+
+	live, latest, cancel := detector.Subscribe(0)
+	defer cancel()
+	if latest != 0 {
+		t.Fatalf("latest = %d before any event", latest)
+	}
+
+	listed = []exchange.Symbol{symbol("AAAUSDT", "TRADING"), symbol("BBBUSDT", "TRADING"), symbol("CCCUSDT", "TRADING")}
+	before := time.Now().UnixMilli()
+	detector.poll(context.Background())
+	var seqs []int64
+	for i := 0; i < 3; i++ {
+		event := <-live
+		if event.Seq < before || (i > 0 && event.Seq <= seqs[i-1]) {
+			t.Fatalf("live event %s has seq %d after %v, want increasing seqs from %d", event.Symbol, event.Seq, seqs, before)
+		}
+		seqs = append(seqs, event.Seq)
+	}
+
+	tests := []struct {
+		name     string
+		afterSeq int64
+		want     []string
+	}{
+		{"live only", 0, nil},
+		{"same poll", seqs[0], []string{"BBBUSDT", "CCCUSDT"}},
+		{"up to date", seqs[2], nil},
+		{"restarted server", before - time.Hour.Milliseconds(), []string{"AAAUSDT", "BBBUSDT", "CCCUSDT"}},
+		{"clock went back", seqs[2] + 7, []string{"AAAUSDT", "BBBUSDT", "CCCUSDT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, latest, cancel := detector.Subscribe(tt.afterSeq)
+			cancel()
+			if latest != seqs[2] {
+				t.Errorf("latest = %d, want %d", latest, seqs[2])
+			}
+			var got []string
+			for event := range events {
+				got = append(got, event.Symbol)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
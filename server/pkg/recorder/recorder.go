@@ -0,0 +1,122 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"github.com/agopankov/imPulse/server/pkg/replay"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const fileTimeFormat = "20060102T150405Z"
+
+// DefaultInterval matches the client's price poll, a replay sees the market
+// as often as a live client did.
+const DefaultInterval = 5 * time.Second
+
+type Recorder struct {
+	mu       sync.Mutex
+	dir      string
+	rotate   time.Duration
+	file     *os.File
+	writer   *gzip.Writer
+	encoder  *json.Encoder
+	openedAt time.Time
+	closed   bool
+}
+
+func New(dir string, rotate time.Duration) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if rotate <= 0 {
+		rotate = time.Hour
+	}
+	return &Recorder{dir: dir, rotate: rotate}, nil
+}
+
+func (r *Recorder) RecordPrices(at time.Time, prices []*proto.USDTPrice) {
+	snapshot := replay.Snapshot{Time: at, Prices: make([]replay.Price, 0, len(prices))}
+	for _, price := range prices {
+		snapshot.Prices = append(snapshot.Prices, replay.Price{Symbol: price.Symbol, Price: price.Price})
+	}
+	r.write(snapshot)
+}
+
+func (r *Recorder) RecordTickers(at time.Time, tickers []*proto.ChangePercent) {
+	snapshot := replay.Snapshot{Time: at, Tickers: make([]replay.Ticker, 0, len(tickers))}
+	for _, ticker := range tickers {
+		snapshot.Tickers = append(snapshot.Tickers, replay.Ticker{
+			Symbol:        ticker.Symbol,
+			ChangePercent: ticker.ChangePercent,
+			QuoteVolume:   ticker.QuoteVolume,
+		})
+	}
+	r.write(snapshot)
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return r.closeFile()
+}
+
+func (r *Recorder) write(snapshot replay.Snapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	if r.file == nil || snapshot.Time.Sub(r.openedAt) >= r.rotate {
+		if err := r.closeFile(); err != nil {
+			log.Printf("Error closing recording file: %v", err)
+		}
+		if err := r.openFile(snapshot.Time); err != nil {
+			log.Printf("Error opening recording file: %v", err)
+			return
+		}
+	}
+
+	if err := r.encoder.Encode(snapshot); err != nil {
+		log.Printf("Error writing snapshot: %v", err)
+		return
+	}
+	if err := r.writer.Flush(); err != nil {
+		log.Printf("Error flushing snapshot: %v", err)
+	}
+}
+
+func (r *Recorder) openFile(at time.Time) error {
+	path := filepath.Join(r.dir, fmt.Sprintf("snapshots-%s.jsonl.gz", at.UTC().Format(fileTimeFormat)))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r.file = file
+	r.writer = gzip.NewWriter(file)
+	r.encoder = json.NewEncoder(r.writer)
+	r.openedAt = at
+	log.Printf("Recording snapshots to %s", path)
+	return nil
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	writerErr := r.writer.Close()
+	fileErr := r.file.Close()
+	r.file, r.writer, r.encoder = nil, nil, nil
+	if writerErr != nil {
+		return writerErr
+	}
+	return fileErr
+}
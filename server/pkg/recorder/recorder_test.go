@@ -0,0 +1,152 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"github.com/agopankov/imPulse/server/pkg/replay"
+)
+
+var start = time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)
+
+func record(t *testing.T, r *Recorder, at time.Time, price float64) {
+	t.Helper()
+	r.RecordPrices(at, []*proto.USDTPrice{{Symbol: "PEPEUSDT", Price: price}})
+	r.RecordTickers(at.Add(100*time.Millisecond), []*proto.ChangePercent{{Symbol: "PEPEUSDT", ChangePercent: price, QuoteVolume: 1000}})
+}
+
+func files(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.jsonl.gz"))
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	return matches
+}
+
+func TestRecorderRotates(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	record(t, r, start, 1)
+	record(t, r, start.Add(59*time.Minute), 2)
+	record(t, r, start.Add(time.Hour), 3)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := files(t, dir)
+	want := []string{
+		filepath.Join(dir, "snapshots-20231114T000000Z.jsonl.gz"),
+		filepath.Join(dir, "snapshots-20231114T010000Z.jsonl.gz"),
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got files %v, want %v", got, want)
+	}
+
+	var count int
+	if err := replay.ReadFile(want[0], func(replay.Snapshot) error { count++; return nil }); err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("first file holds %d lines, want the prices and tickers of two snapshots", count)
+	}
+}
+
+func TestRecorderAppendsAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	record(t, r, start, 1)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// The restarted recorder opens its own file, and one that happens to
+	// have the same name is appended to, not truncated.
+	path := files(t, dir)[0]
+	r, err = New(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	record(t, r, start, 2)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := files(t, dir); len(got) != 1 || got[0] != path {
+		t.Fatalf("got files %v, want only %s", got, path)
+	}
+	var prices []float64
+	err = replay.ReadFile(path, func(snapshot replay.Snapshot) error {
+		for _, price := range snapshot.Prices {
+			prices = append(prices, price.Price)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(prices) != 2 || prices[0] != 1 || prices[1] != 2 {
+		t.Errorf("got prices %v, want both runs", prices)
+	}
+}
+
+func TestRecorderDropsWritesAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	record(t, r, start, 1)
+
+	if got := files(t, dir); len(got) != 0 {
+		t.Errorf("a closed recorder opened %v", got)
+	}
+}
+
+func TestRecordingReplays(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(dir, 30*time.Second)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		record(t, r, start.Add(time.Duration(i)*10*time.Second), float64(i+1))
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "snapshots-20231114T000030Z.jsonl.gz")); err != nil {
+		t.Fatalf("recording didn't rotate: %v", err)
+	}
+
+	snapshots, err := replay.Load([]string{dir})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(snapshots) != 6 {
+		t.Fatalf("got %d snapshots, want the prices and tickers of each merged into 6", len(snapshots))
+	}
+	for i, snapshot := range snapshots {
+		if !snapshot.Time.Equal(start.Add(time.Duration(i) * 10 * time.Second)) {
+			t.Errorf("snapshot %d at %v", i, snapshot.Time)
+		}
+		if len(snapshot.Prices) != 1 || snapshot.Prices[0].Price != float64(i+1) {
+			t.Errorf("snapshot %d prices = %v", i, snapshot.Prices)
+		}
+		if len(snapshot.Tickers) != 1 || snapshot.Tickers[0].ChangePercent != float64(i+1) || snapshot.Tickers[0].QuoteVolume != 1000 {
+			t.Errorf("snapshot %d tickers = %v", i, snapshot.Tickers)
+		}
+	}
+}
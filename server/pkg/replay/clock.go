@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrInvalidSpeed = errors.New("replay speed must be positive")
+
+type Clock struct {
+	mu       sync.Mutex
+	start    time.Time
+	end      time.Time
+	position time.Time
+	anchor   time.Time
+	speed    float64
+	paused   bool
+}
+
+func NewClock(start, end time.Time, speed float64) *Clock {
+	return &Clock{
+		start:    start,
+		end:      end,
+		position: start,
+		anchor:   time.Now(),
+		speed:    speed,
+	}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now(time.Now())
+}
+
+func (c *Clock) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return ErrInvalidSpeed
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebase()
+	c.speed = speed
+	return nil
+}
+
+func (c *Clock) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebase()
+	c.paused = true
+}
+
+func (c *Clock) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebase()
+	c.paused = false
+}
+
+func (c *Clock) Seek(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.position = c.clamp(t)
+	c.anchor = time.Now()
+}
+
+func (c *Clock) Status() (current, start, end time.Time, speed float64, paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now(time.Now()), c.start, c.end, c.speed, c.paused
+}
+
+func (c *Clock) now(wall time.Time) time.Time {
+	if c.paused {
+		return c.position
+	}
+	elapsed := time.Duration(float64(wall.Sub(c.anchor)) * c.speed)
+	return c.clamp(c.position.Add(elapsed))
+}
+
+func (c *Clock) rebase() {
+	wall := time.Now()
+	c.position = c.now(wall)
+	c.anchor = wall
+}
+
+func (c *Clock) clamp(t time.Time) time.Time {
+	if t.Before(c.start) {
+		return c.start
+	}
+	if t.After(c.end) {
+		return c.end
+	}
+	return t
+}
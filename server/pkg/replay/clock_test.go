@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)
+
+// elapse moves the clock's wall clock anchor back, as if wall had passed
+// since the last change.
+func elapse(c *Clock, wall time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.anchor = c.anchor.Add(-wall)
+}
+
+// closeTo allows for the wall clock time the test itself takes, scaled by
+// speed.
+func closeTo(got, want time.Time, speed float64) bool {
+	slack := time.Duration(float64(100*time.Millisecond) * speed)
+	return !got.Before(want) && got.Sub(want) < slack
+}
+
+func TestClockSpeed(t *testing.T) {
+	c := NewClock(start, start.Add(time.Hour), 10)
+	elapse(c, time.Second)
+	if got, want := c.Now(), start.Add(10*time.Second); !closeTo(got, want, 10) {
+		t.Errorf("at 10x after 1s: got %v, want %v", got, want)
+	}
+
+	if err := c.SetSpeed(60); err != nil {
+		t.Fatalf("SetSpeed: %v", err)
+	}
+	elapse(c, time.Second)
+	if got, want := c.Now(), start.Add(70*time.Second); !closeTo(got, want, 60) {
+		t.Errorf("after switching to 60x: got %v, want %v", got, want)
+	}
+
+	for _, speed := range []float64{0, -1} {
+		if err := c.SetSpeed(speed); err != ErrInvalidSpeed {
+			t.Errorf("SetSpeed(%v) = %v, want ErrInvalidSpeed", speed, err)
+		}
+	}
+}
+
+func TestClockPause(t *testing.T) {
+	c := NewClock(start, start.Add(time.Hour), 10)
+	elapse(c, time.Second)
+	c.Pause()
+	paused := c.Now()
+	if !closeTo(paused, start.Add(10*time.Second), 10) {
+		t.Fatalf("paused at %v", paused)
+	}
+
+	elapse(c, time.Minute)
+	if got := c.Now(); !got.Equal(paused) {
+		t.Errorf("clock moved while paused: %v, want %v", got, paused)
+	}
+	if _, _, _, _, isPaused := c.Status(); !isPaused {
+		t.Error("Status doesn't report the pause")
+	}
+
+	c.Resume()
+	elapse(c, time.Second)
+	if got, want := c.Now(), paused.Add(10*time.Second); !closeTo(got, want, 10) {
+		t.Errorf("after resuming: got %v, want %v", got, want)
+	}
+}
+
+func TestClockSeekAndClamp(t *testing.T) {
+	end := start.Add(time.Hour)
+	c := NewClock(start, end, 1)
+	c.Pause()
+
+	c.Seek(start.Add(30 * time.Minute))
+	if got := c.Now(); !got.Equal(start.Add(30 * time.Minute)) {
+		t.Errorf("after seeking: %v", got)
+	}
+	c.Seek(start.Add(-time.Minute))
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("seeking before the recording: got %v, want its start", got)
+	}
+	c.Seek(end.Add(time.Minute))
+	if got := c.Now(); !got.Equal(end) {
+		t.Errorf("seeking past the recording: got %v, want its end", got)
+	}
+
+	c.Resume()
+	c.Seek(end.Add(-time.Second))
+	elapse(c, time.Minute)
+	if got := c.Now(); !got.Equal(end) {
+		t.Errorf("playing past the recording: got %v, want its end", got)
+	}
+}
@@ -0,0 +1,41 @@
+package replay
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+)
+
+// Futures serves an empty futures market. Recordings only hold spot data, so
+// a client with /futures on sees no contracts during a replay instead of an
+// Unimplemented error on every scan.
+type Futures struct {
+	proto.UnimplementedFuturesServiceServer
+}
+
+func (Futures) GetFuturesPrices(context.Context, *proto.Empty) (*proto.USDTPricesResponse, error) {
+	return &proto.USDTPricesResponse{}, nil
+}
+
+func (Futures) GetFutures24HChangePercent(context.Context, *proto.Empty) (*proto.ChangePercentResponse, error) {
+	return &proto.ChangePercentResponse{}, nil
+}
+
+func (Futures) GetFuturesKlines(context.Context, *proto.KlinesRequest) (*proto.KlinesResponse, error) {
+	return &proto.KlinesResponse{}, nil
+}
+
+func (Futures) GetMarkPrices(context.Context, *proto.Empty) (*proto.MarkPricesResponse, error) {
+	return &proto.MarkPricesResponse{}, nil
+}
+
+func (Futures) GetOpenInterest(context.Context, *proto.OpenInterestRequest) (*proto.OpenInterestResponse, error) {
+	return &proto.OpenInterestResponse{}, nil
+}
+
+func (Futures) GetOpenInterestHistory(context.Context, *proto.OpenInterestHistoryRequest) (*proto.OpenInterestResponse, error) {
+	return &proto.OpenInterestResponse{}, nil
+}
+
+func (Futures) GetFundingRates(context.Context, *proto.FundingRatesRequest) (*proto.FundingRatesResponse, error) {
+	return &proto.FundingRatesResponse{}, nil
+}
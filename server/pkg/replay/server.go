@@ -0,0 +1,290 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrNoSnapshots = errors.New("recording contains no snapshots")
+
+var intervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"8h":  8 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+const defaultKlinesLimit = 500
+
+// Server serves a recording as if it were the live market. Timestamps in
+// GetKlines are shifted so that the replay position looks like the current
+// wall clock time to the client.
+//
+// Recordings hold prices, not trades, so the klines are built from the
+// snapshot prices and have no volume. The VWAP baseline finds nothing to
+// average and keeps logging an error, replay with another baseline.
+type Server struct {
+	proto.UnimplementedBinanceServiceServer
+	snapshots []Snapshot
+	// series holds the prices of each symbol in time order, so klines
+	// don't scan every snapshot.
+	series map[string][]point
+	clock  *Clock
+}
+
+type point struct {
+	time  time.Time
+	price float64
+}
+
+type Control struct {
+	proto.UnimplementedReplayControlServer
+	clock *Clock
+}
+
+func NewServer(snapshots []Snapshot, speed float64) (*Server, *Control, error) {
+	if len(snapshots) == 0 {
+		return nil, nil, ErrNoSnapshots
+	}
+	if speed <= 0 {
+		return nil, nil, ErrInvalidSpeed
+	}
+
+	series := make(map[string][]point)
+	for _, snapshot := range snapshots {
+		for _, price := range snapshot.Prices {
+			series[price.Symbol] = append(series[price.Symbol], point{time: snapshot.Time, price: price.Price})
+		}
+	}
+
+	clock := NewClock(snapshots[0].Time, snapshots[len(snapshots)-1].Time, speed)
+	return &Server{snapshots: snapshots, series: series, clock: clock}, &Control{clock: clock}, nil
+}
+
+func (s *Server) GetUSDTPrices(_ context.Context, req *proto.MarketRequest) (*proto.USDTPricesResponse, error) {
+	if err := checkExchange(req.Exchange); err != nil {
+		return nil, err
+	}
+
+	snapshot := s.current()
+	prices := make([]*proto.USDTPrice, 0, len(snapshot.Prices))
+	for _, price := range snapshot.Prices {
+		prices = append(prices, &proto.USDTPrice{
+			Symbol: price.Symbol,
+			Price:  price.Price,
+		})
+	}
+	return &proto.USDTPricesResponse{Prices: prices}, nil
+}
+
+func (s *Server) Get24HChangePercent(_ context.Context, req *proto.MarketRequest) (*proto.ChangePercentResponse, error) {
+	if err := checkExchange(req.Exchange); err != nil {
+		return nil, err
+	}
+
+	snapshot := s.current()
+	changePercents := make([]*proto.ChangePercent, 0, len(snapshot.Tickers))
+	for _, ticker := range snapshot.Tickers {
+		changePercents = append(changePercents, &proto.ChangePercent{
+			Symbol:        ticker.Symbol,
+			ChangePercent: ticker.ChangePercent,
+			QuoteVolume:   ticker.QuoteVolume,
+		})
+	}
+	return &proto.ChangePercentResponse{ChangePercents: changePercents}, nil
+}
+
+func (s *Server) GetKlines(_ context.Context, req *proto.KlinesRequest) (*proto.KlinesResponse, error) {
+	if err := checkExchange(req.Exchange); err != nil {
+		return nil, err
+	}
+
+	interval, ok := intervals[req.Interval]
+	if req.Interval == "" {
+		interval, ok = time.Minute, true
+	}
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported interval %q", req.Interval)
+	}
+
+	now := s.clock.Now()
+	offset := time.Since(now)
+	end := now
+	if req.EndTime > 0 {
+		if requested := time.UnixMilli(req.EndTime).Add(-offset); requested.Before(end) {
+			end = requested
+		}
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultKlinesLimit
+	}
+
+	start := end.Truncate(interval).Add(-time.Duration(limit-1) * interval)
+	if req.StartTime > 0 {
+		start = time.UnixMilli(req.StartTime).Add(-offset).Truncate(interval)
+	}
+
+	klines := s.aggregate(req.Symbol, start, end, interval)
+	if req.StartTime > 0 && len(klines) > limit {
+		klines = klines[:limit]
+	} else if len(klines) > limit {
+		klines = klines[len(klines)-limit:]
+	}
+
+	for _, kline := range klines {
+		kline.OpenTime = time.UnixMilli(kline.OpenTime).Add(offset).UnixMilli()
+		kline.CloseTime = time.UnixMilli(kline.CloseTime).Add(offset).UnixMilli()
+	}
+	return &proto.KlinesResponse{Klines: klines}, nil
+}
+
+func (s *Server) GetSymbols(_ context.Context, req *proto.MarketRequest) (*proto.SymbolsResponse, error) {
+	if err := checkExchange(req.Exchange); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]*proto.SymbolInfo, 0, len(s.current().Prices))
+	for _, price := range s.current().Prices {
+		symbols = append(symbols, &proto.SymbolInfo{
+			Symbol:     price.Symbol,
+			BaseAsset:  strings.TrimSuffix(price.Symbol, "USDT"),
+			QuoteAsset: "USDT",
+			Trading:    true,
+		})
+	}
+	return &proto.SymbolsResponse{Symbols: symbols}, nil
+}
+
+func (s *Server) GetExchanges(context.Context, *proto.Empty) (*proto.ExchangesResponse, error) {
+	return &proto.ExchangesResponse{Exchanges: []string{"binance"}}, nil
+}
+
+// GetOrderBook answers with an empty book, recordings hold no depth, so
+// replayed pump alerts go out without liquidity figures.
+func (s *Server) GetOrderBook(_ context.Context, req *proto.OrderBookRequest) (*proto.OrderBook, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	return &proto.OrderBook{
+		Symbol:    req.Symbol,
+		Time:      s.clock.Now().UnixMilli(),
+		Liquidity: &proto.Liquidity{BandPercent: req.BandPercent},
+	}, nil
+}
+
+// checkExchange rejects other venues, recordings only hold Binance data.
+func checkExchange(name string) error {
+	if name != "" && !strings.EqualFold(name, "binance") {
+		return status.Errorf(codes.InvalidArgument, "replay only serves binance, not %q", name)
+	}
+	return nil
+}
+
+func (c *Control) GetStatus(_ context.Context, _ *proto.Empty) (*proto.ReplayStatus, error) {
+	return c.status(), nil
+}
+
+func (c *Control) SetSpeed(_ context.Context, req *proto.SetSpeedRequest) (*proto.ReplayStatus, error) {
+	if err := c.clock.SetSpeed(req.Speed); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return c.status(), nil
+}
+
+func (c *Control) Pause(_ context.Context, _ *proto.Empty) (*proto.ReplayStatus, error) {
+	c.clock.Pause()
+	return c.status(), nil
+}
+
+func (c *Control) Resume(_ context.Context, _ *proto.Empty) (*proto.ReplayStatus, error) {
+	c.clock.Resume()
+	return c.status(), nil
+}
+
+func (c *Control) Seek(_ context.Context, req *proto.SeekRequest) (*proto.ReplayStatus, error) {
+	if req.Time <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "seek time must be a unix timestamp in milliseconds")
+	}
+	c.clock.Seek(time.UnixMilli(req.Time))
+	return c.status(), nil
+}
+
+func (c *Control) status() *proto.ReplayStatus {
+	current, start, end, speed, paused := c.clock.Status()
+	return &proto.ReplayStatus{
+		CurrentTime: current.UnixMilli(),
+		StartTime:   start.UnixMilli(),
+		EndTime:     end.UnixMilli(),
+		Speed:       speed,
+		Paused:      paused,
+	}
+}
+
+func (s *Server) current() Snapshot {
+	return s.snapshots[s.index(s.clock.Now())]
+}
+
+func (s *Server) index(t time.Time) int {
+	i := sort.Search(len(s.snapshots), func(i int) bool {
+		return s.snapshots[i].Time.After(t)
+	})
+	if i == 0 {
+		return 0
+	}
+	return i - 1
+}
+
+func (s *Server) aggregate(symbol string, start, end time.Time, interval time.Duration) []*proto.Kline {
+	points := s.series[symbol]
+	first := sort.Search(len(points), func(i int) bool {
+		return !points[i].time.Before(start)
+	})
+
+	var klines []*proto.Kline
+	var current *proto.Kline
+	for _, point := range points[first:] {
+		if point.time.After(end) {
+			break
+		}
+
+		openTime := point.time.Truncate(interval)
+		if current == nil || current.OpenTime != openTime.UnixMilli() {
+			current = &proto.Kline{
+				OpenTime:  openTime.UnixMilli(),
+				Open:      point.price,
+				High:      point.price,
+				Low:       point.price,
+				CloseTime: openTime.Add(interval).UnixMilli() - 1,
+			}
+			klines = append(klines, current)
+		}
+		if point.price > current.High {
+			current.High = point.price
+		}
+		if point.price < current.Low {
+			current.Low = point.price
+		}
+		current.Close = point.price
+	}
+	return klines
+}
+
+func (s *Server) String() string {
+	return fmt.Sprintf("replay of %d snapshots from %s to %s", len(s.snapshots), s.snapshots[0].Time.Format(time.RFC3339), s.snapshots[len(s.snapshots)-1].Time.Format(time.RFC3339))
+}
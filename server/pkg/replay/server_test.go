@@ -0,0 +1,112 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/server/pkg/grpcbinance/proto"
+)
+
+// recording has a PEPEUSDT price every 20 seconds for ten minutes, rising by
+// one each time, and a BTCUSDT price only in the first minute.
+func recording() []Snapshot {
+	var snapshots []Snapshot
+	for i := 0; i <= 30; i++ {
+		prices := []Price{{Symbol: "PEPEUSDT", Price: float64(i + 1)}}
+		if i < 3 {
+			prices = append(prices, Price{Symbol: "BTCUSDT", Price: 36500})
+		}
+		snapshots = append(snapshots, Snapshot{Time: start.Add(time.Duration(i) * 20 * time.Second), Prices: prices})
+	}
+	return snapshots
+}
+
+func newServer(t *testing.T) *Server {
+	t.Helper()
+	server, _, err := NewServer(recording(), 1)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return server
+}
+
+func TestAggregate(t *testing.T) {
+	server := newServer(t)
+
+	klines := server.aggregate("PEPEUSDT", start.Add(time.Minute), start.Add(3*time.Minute), time.Minute)
+	want := []proto.Kline{
+		{OpenTime: start.Add(time.Minute).UnixMilli(), Open: 4, High: 6, Low: 4, Close: 6},
+		{OpenTime: start.Add(2 * time.Minute).UnixMilli(), Open: 7, High: 9, Low: 7, Close: 9},
+		{OpenTime: start.Add(3 * time.Minute).UnixMilli(), Open: 10, High: 10, Low: 10, Close: 10},
+	}
+	if len(klines) != len(want) {
+		t.Fatalf("got %d klines, want %d", len(klines), len(want))
+	}
+	for i, kline := range klines {
+		want[i].CloseTime = want[i].OpenTime + time.Minute.Milliseconds() - 1
+		if kline.OpenTime != want[i].OpenTime || kline.CloseTime != want[i].CloseTime || kline.Open != want[i].Open ||
+			kline.High != want[i].High || kline.Low != want[i].Low || kline.Close != want[i].Close || kline.Volume != 0 {
+			t.Errorf("kline %d = %+v, want %+v", i, kline, &want[i])
+		}
+	}
+
+	if klines := server.aggregate("PEPEUSDT", start, start.Add(10*time.Minute), 5*time.Minute); len(klines) != 3 || klines[0].Close != 15 || klines[1].Open != 16 {
+		t.Errorf("5m klines = %v", klines)
+	}
+	if klines := server.aggregate("BTCUSDT", start, start.Add(10*time.Minute), time.Minute); len(klines) != 1 {
+		t.Errorf("got %d BTCUSDT klines, want only the first minute", len(klines))
+	}
+	if klines := server.aggregate("DOGEUSDT", start, start.Add(10*time.Minute), time.Minute); len(klines) != 0 {
+		t.Errorf("got klines for a symbol that isn't recorded: %v", klines)
+	}
+}
+
+func TestGetKlinesShiftsToWallClock(t *testing.T) {
+	server := newServer(t)
+	server.clock.Pause()
+	position := start.Add(5*time.Minute + 30*time.Second)
+	server.clock.Seek(position)
+
+	before := time.Now()
+	resp, err := server.GetKlines(context.Background(), &proto.KlinesRequest{Symbol: "PEPEUSDT", Interval: "1m", Limit: 3})
+	if err != nil {
+		t.Fatalf("GetKlines: %v", err)
+	}
+	if len(resp.Klines) != 3 {
+		t.Fatalf("got %d klines, want 3", len(resp.Klines))
+	}
+
+	// The last kline opened 30s before the replay position, which the
+	// client sees as now.
+	last := resp.Klines[2]
+	if opened := time.UnixMilli(last.OpenTime); opened.Before(before.Add(-31*time.Second)) || opened.After(time.Now().Add(-29*time.Second)) {
+		t.Errorf("last kline opened at %v, want about 30s before %v", opened, before)
+	}
+	if last.Open != 16 || last.Close != 17 {
+		t.Errorf("last kline = %+v, want the one up to the replay position", last)
+	}
+
+	// A start time in wall clock terms maps back onto the recording. The
+	// offset grows while the test runs, so the start is mid candle.
+	offset := time.UnixMilli(last.OpenTime).Sub(start.Add(5 * time.Minute))
+	resp, err = server.GetKlines(context.Background(), &proto.KlinesRequest{
+		Symbol:    "PEPEUSDT",
+		Interval:  "1m",
+		StartTime: start.Add(90 * time.Second).Add(offset).UnixMilli(),
+		Limit:     2,
+	})
+	if err != nil {
+		t.Fatalf("GetKlines: %v", err)
+	}
+	if len(resp.Klines) != 2 || resp.Klines[0].Open != 4 || resp.Klines[1].Open != 7 {
+		t.Errorf("klines from a start time = %v", resp.Klines)
+	}
+
+	if _, err := server.GetKlines(context.Background(), &proto.KlinesRequest{Symbol: "PEPEUSDT", Interval: "7m"}); err == nil {
+		t.Error("accepted an unsupported interval")
+	}
+	if _, err := server.GetKlines(context.Background(), &proto.KlinesRequest{Exchange: "bybit", Symbol: "PEPEUSDT"}); err == nil {
+		t.Error("served another venue")
+	}
+}
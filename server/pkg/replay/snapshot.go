@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const mergeWindow = 2 * time.Second
+
+type Snapshot struct {
+	Time    time.Time `json:"time"`
+	Prices  []Price   `json:"prices,omitempty"`
+	Tickers []Ticker  `json:"tickers,omitempty"`
+}
+
+type Price struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
+
+type Ticker struct {
+	Symbol        string  `json:"symbol"`
+	ChangePercent float64 `json:"change_percent"`
+	QuoteVolume   float64 `json:"quote_volume"`
+}
+
+// Load reads JSONL snapshot files, gzip compressed when they end in .gz.
+// Directories are expanded to the .jsonl and .jsonl.gz files they contain.
+// The recorder writes prices and 24h stats as separate lines, so a snapshot
+// missing either part inherits it from the previous one.
+func Load(paths []string) ([]Snapshot, error) {
+	var files []string
+	for _, path := range paths {
+		expanded, err := snapshotFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, expanded...)
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		err := ReadFile(file, func(snapshot Snapshot) error {
+			snapshots = append(snapshots, snapshot)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	snapshots = mergeParts(snapshots)
+	for i := 1; i < len(snapshots); i++ {
+		if snapshots[i].Prices == nil {
+			snapshots[i].Prices = snapshots[i-1].Prices
+		}
+		if snapshots[i].Tickers == nil {
+			snapshots[i].Tickers = snapshots[i-1].Tickers
+		}
+	}
+	return snapshots, nil
+}
+
+func mergeParts(snapshots []Snapshot) []Snapshot {
+	merged := snapshots[:0]
+	for _, snapshot := range snapshots {
+		if n := len(merged); n > 0 && snapshot.Time.Sub(merged[n-1].Time) < mergeWindow {
+			previous := &merged[n-1]
+			if previous.Prices == nil && snapshot.Tickers == nil {
+				previous.Prices = snapshot.Prices
+				continue
+			}
+			if previous.Tickers == nil && snapshot.Prices == nil {
+				previous.Tickers = snapshot.Tickers
+				continue
+			}
+		}
+		merged = append(merged, snapshot)
+	}
+	return merged
+}
+
+func ReadFile(path string, fn func(Snapshot) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	err = Read(reader, fn)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
+
+func Read(reader io.Reader, fn func(Snapshot) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(snapshot); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func snapshotFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(file, ".jsonl") || strings.HasSuffix(file, ".jsonl.gz") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
@@ -0,0 +1,114 @@
+package whales
+
+import (
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+	"time"
+)
+
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+)
+
+// Settings are the notional thresholds in quote asset (USDT). A single trade
+// of at least MinNotional is reported on its own; smaller trades on the same
+// side adding up to BurstNotional within BurstWindow are reported together.
+// A zero threshold turns that kind of event off.
+type Settings struct {
+	MinNotional   float64
+	BurstNotional float64
+	BurstWindow   time.Duration
+}
+
+type Event struct {
+	Symbol   string
+	Side     string
+	Price    float64
+	Quantity float64
+	Notional float64
+	Trades   int
+	Time     int64
+	Burst    bool
+}
+
+type burst struct {
+	start    int64
+	quantity float64
+	notional float64
+	trades   int
+}
+
+// Detector turns trades into events for one subscriber. Bursts that ran out
+// of window are dropped once a window, so symbols that stop trading don't
+// pile up. It is not safe for concurrent use.
+type Detector struct {
+	settings Settings
+	bursts   map[string]*burst
+	prunedAt int64
+}
+
+func NewDetector(settings Settings) *Detector {
+	return &Detector{settings: settings, bursts: make(map[string]*burst)}
+}
+
+func (d *Detector) Add(trade exchange.AggTrade) (Event, bool) {
+	side := SideBuy
+	if trade.BuyerMaker {
+		side = SideSell
+	}
+	notional := trade.Price * trade.Quantity
+
+	if d.settings.MinNotional > 0 && notional >= d.settings.MinNotional {
+		return Event{
+			Symbol:   trade.Symbol,
+			Side:     side,
+			Price:    trade.Price,
+			Quantity: trade.Quantity,
+			Notional: notional,
+			Trades:   1,
+			Time:     trade.Time,
+		}, true
+	}
+	if d.settings.BurstNotional <= 0 || d.settings.BurstWindow <= 0 {
+		return Event{}, false
+	}
+
+	window := d.settings.BurstWindow.Milliseconds()
+	if trade.Time-d.prunedAt > window {
+		d.prune(trade.Time, window)
+	}
+
+	key := trade.Symbol + "/" + side
+	current, ok := d.bursts[key]
+	if !ok || trade.Time-current.start > window {
+		current = &burst{start: trade.Time}
+		d.bursts[key] = current
+	}
+	current.quantity += trade.Quantity
+	current.notional += notional
+	current.trades++
+	if current.notional < d.settings.BurstNotional {
+		return Event{}, false
+	}
+
+	delete(d.bursts, key)
+	return Event{
+		Symbol:   trade.Symbol,
+		Side:     side,
+		Price:    current.notional / current.quantity,
+		Quantity: current.quantity,
+		Notional: current.notional,
+		Trades:   current.trades,
+		Time:     trade.Time,
+		Burst:    true,
+	}, true
+}
+
+func (d *Detector) prune(now int64, window int64) {
+	for key, current := range d.bursts {
+		if now-current.start > window {
+			delete(d.bursts, key)
+		}
+	}
+	d.prunedAt = now
+}
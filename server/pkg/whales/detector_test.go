@@ -0,0 +1,32 @@
+package whales
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+)
+
+func TestDetectorBurstAndPrune(t *testing.T) {
+	detector := NewDetector(Settings{MinNotional: 100000, BurstNotional: 50000, BurstWindow: 10 * time.Second})
+
+	if _, ok := detector.Add(exchange.AggTrade{Symbol: "PEPEUSDT", Price: 1, Quantity: 30000, Time: 1000}); ok {
+		t.Fatal("first part of a burst was reported")
+	}
+	event, ok := detector.Add(exchange.AggTrade{Symbol: "PEPEUSDT", Price: 1, Quantity: 30000, Time: 5000})
+	if !ok || !event.Burst || event.Notional != 60000 || event.Trades != 2 {
+		t.Fatalf("got %+v, %v, want a 60000 burst of 2 trades", event, ok)
+	}
+
+	detector.Add(exchange.AggTrade{Symbol: "DOGEUSDT", Price: 1, Quantity: 1000, Time: 6000})
+	if len(detector.bursts) != 1 {
+		t.Fatalf("got %d open bursts, want 1", len(detector.bursts))
+	}
+	detector.Add(exchange.AggTrade{Symbol: "BTCUSDT", Price: 1, Quantity: 1000, BuyerMaker: true, Time: 30000})
+	if _, ok := detector.bursts["DOGEUSDT/buy"]; ok {
+		t.Error("expired DOGEUSDT burst was kept")
+	}
+	if len(detector.bursts) != 1 {
+		t.Errorf("got %d open bursts, want only BTCUSDT/sell", len(detector.bursts))
+	}
+}
@@ -0,0 +1,215 @@
+package whales
+
+import (
+	"context"
+	"github.com/agopankov/imPulse/server/pkg/exchange"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// InterestTTL is how long a client's symbols are watched after it last
+	// registered them.
+	InterestTTL = 5 * time.Minute
+	// MaxSymbols is the number of streams Binance allows on one connection.
+	MaxSymbols = 1024
+
+	reconnectDelay = 5 * time.Second
+	eventBuffer    = 64
+)
+
+// Source streams aggregate trades of the symbols until the context ends.
+type Source func(ctx context.Context, symbols []string, handle func(exchange.AggTrade) error) error
+
+type interest struct {
+	symbols map[string]bool
+	expires time.Time
+}
+
+type subscriber struct {
+	clientID string
+	detector *Detector
+	events   chan Event
+}
+
+// Hub keeps one trade stream for the symbols all clients are interested in
+// and hands every trade to the detectors of the subscribers watching it. The
+// stream is reopened whenever the set of symbols changes.
+type Hub struct {
+	source Source
+
+	mu          sync.Mutex
+	interests   map[string]interest
+	subscribers map[*subscriber]bool
+	watching    []string
+	changed     chan struct{}
+	once        sync.Once
+}
+
+func NewHub(source Source) *Hub {
+	return &Hub{
+		source:      source,
+		interests:   make(map[string]interest),
+		subscribers: make(map[*subscriber]bool),
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+// SetInterest replaces the symbols watched for a client. An empty list drops
+// the client's interest.
+func (h *Hub) SetInterest(clientID string, symbols []string) {
+	h.once.Do(func() { go h.run() })
+
+	h.mu.Lock()
+	if len(symbols) == 0 {
+		delete(h.interests, clientID)
+	} else {
+		set := make(map[string]bool, len(symbols))
+		for _, symbol := range symbols {
+			set[strings.ToUpper(symbol)] = true
+		}
+		h.interests[clientID] = interest{symbols: set, expires: time.Now().Add(InterestTTL)}
+	}
+	h.mu.Unlock()
+
+	h.notify()
+}
+
+// Subscribe returns the events for the symbols the client is interested in.
+// The channel is closed by the returned cancel function; events are dropped
+// while the subscriber is not keeping up.
+func (h *Hub) Subscribe(clientID string, settings Settings) (<-chan Event, func()) {
+	sub := &subscriber{clientID: clientID, detector: NewDetector(settings), events: make(chan Event, eventBuffer)}
+	h.mu.Lock()
+	h.subscribers[sub] = true
+	h.mu.Unlock()
+
+	return sub.events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.subscribers[sub] {
+			delete(h.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (h *Hub) notify() {
+	select {
+	case h.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (h *Hub) run() {
+	expiry := time.NewTicker(time.Minute)
+	defer expiry.Stop()
+
+	for {
+		symbols := h.symbols()
+		if len(symbols) == 0 {
+			select {
+			case <-h.changed:
+			case <-expiry.C:
+				h.expire()
+			}
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error, 1)
+		log.Printf("Watching trades of %d symbols", len(symbols))
+		go func() {
+			errC <- h.source(ctx, symbols, h.dispatch)
+		}()
+
+	watch:
+		for {
+			select {
+			case <-h.changed:
+			case <-expiry.C:
+				h.expire()
+			case err := <-errC:
+				log.Printf("Trade stream stopped: %v", err)
+				cancel()
+				time.Sleep(reconnectDelay)
+				break watch
+			}
+			if !equalSymbols(h.symbols(), symbols) {
+				cancel()
+				<-errC
+				break watch
+			}
+		}
+	}
+}
+
+func (h *Hub) dispatch(trade exchange.AggTrade) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if !h.interests[sub.clientID].symbols[trade.Symbol] {
+			continue
+		}
+		event, ok := sub.detector.Add(trade)
+		if !ok {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("Dropped whale event for %s, client %s is not keeping up", trade.Symbol, sub.clientID)
+		}
+	}
+	return nil
+}
+
+func (h *Hub) expire() {
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for clientID, interest := range h.interests {
+		if now.After(interest.expires) {
+			delete(h.interests, clientID)
+		}
+	}
+}
+
+// symbols is the sorted union of all interests, cut at MaxSymbols.
+func (h *Hub) symbols() []string {
+	h.mu.Lock()
+	set := make(map[string]bool)
+	for _, interest := range h.interests {
+		for symbol := range interest.symbols {
+			set[symbol] = true
+		}
+	}
+	h.mu.Unlock()
+
+	symbols := make([]string, 0, len(set))
+	for symbol := range set {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	if len(symbols) > MaxSymbols {
+		log.Printf("Watching only %d of %d symbols", MaxSymbols, len(symbols))
+		symbols = symbols[:MaxSymbols]
+	}
+	return symbols
+}
+
+func equalSymbols(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}